@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jimschubert/ignore"
+	"github.com/jimschubert/ignore/strategy"
+)
+
+// exit codes match those of git check-ignore
+const (
+	exitIgnored    = 0
+	exitNotIgnored = 1
+	exitFatal      = 128
+)
+
+type checkOptions struct {
+	ignoreFile  string
+	strategy    string
+	nested      bool
+	verbose     bool
+	nonMatching bool
+	stdin       bool
+	nulDelim    bool
+}
+
+// check reports which paths are excluded, returning 0 if any path is excluded, 1 if none are, or 128 on error
+func check(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	opts := checkOptions{}
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprint(stderr, "usage: ignore check [<options>] <pathname>...\n   or: ignore check [<options>] --stdin\n\n")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&opts.ignoreFile, "ignore-file", ".gitignore", "the ignore `file` to evaluate paths against")
+	flags.StringVar(&opts.strategy, "strategy", "", "the `name` of the strategy for parsing the ignore file (one of "+strings.Join(strategy.Names(), ", ")+"); by default, detected from the ignore file's name, or gitignore")
+	flags.BoolVar(&opts.nested, "nested", false, "also apply ignore files of the same name in subdirectories of the ignore file's directory")
+	flags.BoolVar(&opts.verbose, "verbose", false, "output details about the matching pattern (if any) for each path")
+	flags.BoolVar(&opts.verbose, "v", false, "shorthand for --verbose")
+	flags.BoolVar(&opts.nonMatching, "non-matching", false, "show given paths which don't match any pattern (requires --verbose)")
+	flags.BoolVar(&opts.nonMatching, "n", false, "shorthand for --non-matching")
+	flags.BoolVar(&opts.stdin, "stdin", false, "read pathnames from standard input, one per line")
+	flags.BoolVar(&opts.nulDelim, "z", false, "input and output records are terminated by NUL rather than newline")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return exitFatal
+	}
+
+	paths := flags.Args()
+	switch {
+	case opts.stdin && len(paths) > 0:
+		return fatal(stderr, "cannot specify pathnames with --stdin")
+	case !opts.stdin && len(paths) == 0:
+		return fatal(stderr, "no path specified")
+	case opts.nonMatching && !opts.verbose:
+		return fatal(stderr, "--non-matching is only valid with --verbose")
+	}
+
+	processor, err := newCheckProcessor(opts)
+	if err != nil {
+		return fatal(stderr, err.Error())
+	}
+
+	if opts.stdin {
+		if paths, err = readPaths(stdin, opts.nulDelim); err != nil {
+			return fatal(stderr, err.Error())
+		}
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer func(out *bufio.Writer) {
+		_ = out.Flush()
+	}(out)
+
+	cwd, _ := os.Getwd()
+	anyIgnored := false
+	for _, path := range paths {
+		match, err := explain(processor, path)
+		if err != nil {
+			_ = out.Flush()
+			return fatal(stderr, err.Error())
+		}
+
+		if !match.Allowed {
+			anyIgnored = true
+		}
+
+		writeMatch(out, match, opts, cwd)
+	}
+
+	if anyIgnored {
+		return exitIgnored
+	}
+	return exitNotIgnored
+}
+
+func newCheckProcessor(opts checkOptions) (*ignore.Processor, error) {
+	strategyOption := ignore.WithGitignoreStrategy()
+	if opts.strategy != "" {
+		strategyOption = ignore.WithStrategyNamed(opts.strategy)
+	} else if s, ok := strategy.ForFile(opts.ignoreFile); ok {
+		strategyOption = ignore.WithStrategy(s)
+	}
+
+	options := []ignore.ProcessorOption{
+		strategyOption,
+		ignore.WithIgnoreFilePath(opts.ignoreFile),
+	}
+	if opts.nested {
+		options = append(options, ignore.WithNestedIgnoreFiles(filepath.Dir(opts.ignoreFile)))
+	}
+
+	return ignore.NewProcessor(options...)
+}
+
+// explain evaluates path, which is relative to the working directory as for git check-ignore, describing it as given.
+// A path outside the ignore file's directory matches none of its patterns.
+func explain(processor *ignore.Processor, path string) (ignore.Match, error) {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return ignore.Match{Path: path}, err
+	}
+	if strings.HasSuffix(filepath.ToSlash(path), "/") {
+		// a trailing separator marks a directory
+		absolute += string(filepath.Separator)
+	}
+
+	match, err := processor.Explain(absolute)
+	if errors.Is(err, ignore.ErrOutsideBase) {
+		return ignore.Match{Path: path, Allowed: true}, nil
+	}
+
+	match.Path = path
+	return match, err
+}
+
+// readPaths reads newline (or NUL) delimited paths from reader
+func readPaths(reader io.Reader, nulDelim bool) ([]string, error) {
+	scanner := bufio.NewScanner(reader)
+	if nulDelim {
+		scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+			if i := bytes.IndexByte(data, 0); i >= 0 {
+				return i + 1, data[:i], nil
+			}
+			if atEOF && len(data) > 0 {
+				return len(data), data, nil
+			}
+			return 0, nil, nil
+		})
+	}
+
+	paths := make([]string, 0)
+	for scanner.Scan() {
+		path := scanner.Text()
+		if !nulDelim {
+			path = strings.TrimSuffix(path, "\r")
+		}
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, scanner.Err()
+}
+
+// writeMatch outputs match in the format of git check-ignore
+func writeMatch(out io.Writer, match ignore.Match, opts checkOptions, cwd string) {
+	terminator := "\n"
+	if opts.nulDelim {
+		terminator = "\x00"
+	}
+
+	if !opts.verbose {
+		if !match.Allowed {
+			_, _ = fmt.Fprintf(out, "%s%s", match.Path, terminator)
+		}
+		return
+	}
+
+	if !match.Matched() {
+		if opts.nonMatching {
+			if opts.nulDelim {
+				_, _ = fmt.Fprintf(out, "\x00\x00\x00%s\x00", match.Path)
+			} else {
+				_, _ = fmt.Fprintf(out, "::\t%s\n", match.Path)
+			}
+		}
+		return
+	}
+
+	// : as with git, the source is relative to the working directory, even when that's within a parent directory
+	source := match.Source
+	if relative, err := filepath.Rel(cwd, source); err == nil {
+		source = relative
+	}
+
+	if opts.nulDelim {
+		_, _ = fmt.Fprintf(out, "%s\x00%d\x00%s\x00%s\x00", source, match.Line, match.Pattern, match.Path)
+	} else {
+		_, _ = fmt.Fprintf(out, "%s:%d:%s\t%s\n", source, match.Line, match.Pattern, match.Path)
+	}
+}
+
+func fatal(stderr io.Writer, message string) int {
+	_, _ = fmt.Fprintf(stderr, "fatal: %s\n", message)
+	return exitFatal
+}
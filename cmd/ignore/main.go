@@ -0,0 +1,44 @@
+// Command ignore evaluates paths against ignore files.
+//
+// Usage:
+//
+//	ignore check [options] pathname...
+//	ignore check [options] --stdin
+//
+// The check subcommand behaves like `git check-ignore`, printing each given path which is excluded by the ignore file.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage: ignore <command> [<args>]
+
+commands:
+  check    report which paths are excluded by an ignore file
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command described by args, returning the process exit code
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+		return exitFatal
+	}
+
+	switch args[0] {
+	case "check":
+		return check(args[1:], stdin, stdout, stderr)
+	case "help", "-h", "--help":
+		_, _ = fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		_, _ = fmt.Fprintf(stderr, "ignore: '%s' is not a command\n\n%s", args[0], usage)
+		return exitFatal
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	ignoreFile := filepath.Join(dir, ".gitignore")
+	if err := os.WriteFile(ignoreFile, []byte("# logs\n*.log\n!keep.log\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dockerignoreFile := filepath.Join(dir, ".dockerignore")
+	if err := os.WriteFile(dockerignoreFile, []byte("vendor\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	filterFile := filepath.Join(dir, "filters")
+	if err := os.WriteFile(filterFile, []byte("+ keep.tmp\n- *.tmp\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// as for git check-ignore, paths are relative to the working directory
+	chdir(t, dir)
+
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantOut    string
+		wantErrOut string
+		wantCode   int
+	}{
+		{
+			name:     "prints ignored paths",
+			args:     []string{"check", "--ignore-file", ignoreFile, "a.log", "b.txt", "keep.log"},
+			wantOut:  "a.log\n",
+			wantCode: exitIgnored,
+		},
+		{
+			name:     "exits 1 when nothing is ignored",
+			args:     []string{"check", "--ignore-file", ignoreFile, "b.txt", "keep.log"},
+			wantOut:  "",
+			wantCode: exitNotIgnored,
+		},
+		{
+			name:     "verbose prints matching patterns including negations",
+			args:     []string{"check", "--ignore-file", ignoreFile, "-v", "a.log", "b.txt", "keep.log"},
+			wantOut:  ".gitignore:2:*.log\ta.log\n.gitignore:3:!keep.log\tkeep.log\n",
+			wantCode: exitIgnored,
+		},
+		{
+			name:     "verbose non-matching",
+			args:     []string{"check", "--ignore-file", ignoreFile, "--verbose", "--non-matching", "b.txt"},
+			wantOut:  "::\tb.txt\n",
+			wantCode: exitNotIgnored,
+		},
+		{
+			name:     "reads paths from stdin",
+			args:     []string{"check", "--ignore-file", ignoreFile, "--stdin"},
+			stdin:    "a.log\nb.txt\nc.log\n",
+			wantOut:  "a.log\nc.log\n",
+			wantCode: exitIgnored,
+		},
+		{
+			name:     "reads NUL delimited paths from stdin",
+			args:     []string{"check", "--ignore-file", ignoreFile, "--stdin", "-z", "-v"},
+			stdin:    "a.log\x00b.txt\x00",
+			wantOut:  ".gitignore\x002\x00*.log\x00a.log\x00",
+			wantCode: exitIgnored,
+		},
+		{
+			name:       "requires a path",
+			args:       []string{"check", "--ignore-file", ignoreFile},
+			wantErrOut: "fatal: no path specified\n",
+			wantCode:   exitFatal,
+		},
+		{
+			name:       "rejects paths with stdin",
+			args:       []string{"check", "--ignore-file", ignoreFile, "--stdin", "a.log"},
+			wantErrOut: "fatal: cannot specify pathnames with --stdin\n",
+			wantCode:   exitFatal,
+		},
+		{
+			name:       "non-matching requires verbose",
+			args:       []string{"check", "--ignore-file", ignoreFile, "-n", "a.log"},
+			wantErrOut: "fatal: --non-matching is only valid with --verbose\n",
+			wantCode:   exitFatal,
+		},
+		{
+			name:     "missing ignore file",
+			args:     []string{"check", "--ignore-file", filepath.Join(dir, "missing"), "a.log"},
+			wantCode: exitFatal,
+		},
+		{
+			name:     "detects the strategy from the ignore file name",
+			args:     []string{"check", "--ignore-file", dockerignoreFile, "vendor/lib/a.go", "src/a.go"},
+			wantOut:  "vendor/lib/a.go\n",
+			wantCode: exitIgnored,
+		},
+		{
+			name:     "applies a named strategy",
+			args:     []string{"check", "--ignore-file", filterFile, "--strategy", "rsync-filter", "a.tmp", "keep.tmp"},
+			wantOut:  "a.tmp\n",
+			wantCode: exitIgnored,
+		},
+		{
+			name:     "unknown strategy",
+			args:     []string{"check", "--ignore-file", ignoreFile, "--strategy", "nope", "a.log"},
+			wantCode: exitFatal,
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"nope"},
+			wantCode: exitFatal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := bytes.Buffer{}
+			stderr := bytes.Buffer{}
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("run() code = %v, want %v (stderr: %s)", code, tt.wantCode, stderr.String())
+			}
+			if got := stdout.String(); got != tt.wantOut {
+				t.Errorf("run() stdout = %q, want %q", got, tt.wantOut)
+			}
+			if tt.wantErrOut != "" && stderr.String() != tt.wantErrOut {
+				t.Errorf("run() stderr = %q, want %q", stderr.String(), tt.wantErrOut)
+			}
+		})
+	}
+}
+
+func TestRun_workingDirectory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", ".gitignore"), []byte("*.log\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, dir)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantOut  string
+		wantCode int
+	}{
+		{
+			name:     "path outside the ignore file's directory",
+			args:     []string{"check", "-v", "--ignore-file", filepath.Join("sub", ".gitignore"), "a.log"},
+			wantOut:  "",
+			wantCode: exitNotIgnored,
+		},
+		{
+			name:     "path within the ignore file's directory",
+			args:     []string{"check", "-v", "--ignore-file", filepath.Join("sub", ".gitignore"), "a.log", "sub/b.log"},
+			wantOut:  filepath.Join("sub", ".gitignore") + ":1:*.log\tsub/b.log\n",
+			wantCode: exitIgnored,
+		},
+		{
+			name:     "absolute path",
+			args:     []string{"check", "--ignore-file", filepath.Join("sub", ".gitignore"), filepath.Join(dir, "sub", "c.log")},
+			wantOut:  filepath.Join(dir, "sub", "c.log") + "\n",
+			wantCode: exitIgnored,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := bytes.Buffer{}
+			stderr := bytes.Buffer{}
+			code := run(tt.args, strings.NewReader(""), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("run() code = %v, want %v (stderr: %s)", code, tt.wantCode, stderr.String())
+			}
+			if got := stdout.String(); got != tt.wantOut {
+				t.Errorf("run() stdout = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+// chdir changes the working directory to dir for the remainder of the test
+func TestRun_subdirectory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("# logs\n*.log\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, filepath.Join(dir, "sub"))
+
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+	code := run([]string{"check", "-v", "--ignore-file", filepath.Join("..", ".gitignore"), "a.log"}, strings.NewReader(""), &stdout, &stderr)
+	if code != exitIgnored {
+		t.Errorf("run() code = %v, want %v (stderr: %s)", code, exitIgnored, stderr.String())
+	}
+	if got, want := stdout.String(), filepath.Join("..", ".gitignore")+":2:*.log\ta.log\n"; got != want {
+		t.Errorf("run() stdout = %q, want %q", got, want)
+	}
+}
+
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+}
@@ -0,0 +1,114 @@
+package ignore
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrOutsideBase is reported for a path outside the base directory of the Processor, i.e. the directory containing the
+// ignore file (or the root given to WithNestedIgnoreFiles), to which no ignore rule can apply
+var ErrOutsideBase = errors.New("path is outside the base directory")
+
+// open opens the named file from the Processor's file system, or the operating system's if none was provided
+func (p *Processor) open(name string) (fs.File, error) {
+	if p.fsys == nil {
+		return os.Open(name)
+	}
+	return p.fsys.Open(filepath.ToSlash(name))
+}
+
+// stat describes the named file from the Processor's file system, or the operating system's if none was provided
+func (p *Processor) stat(name string) (fs.FileInfo, error) {
+	if p.fsys == nil {
+		return os.Stat(name)
+	}
+	return fs.Stat(p.fsys, filepath.ToSlash(name))
+}
+
+// walkDir walks the tree at root within the Processor's file system, or the operating system's if none was provided
+func (p *Processor) walkDir(root string, fn fs.WalkDirFunc) error {
+	if p.fsys == nil {
+		return filepath.WalkDir(root, fn)
+	}
+	return fs.WalkDir(p.fsys, filepath.ToSlash(root), fn)
+}
+
+// join joins path elements using the separator appropriate for the Processor's file system
+func (p *Processor) join(elem ...string) string {
+	if p.fsys == nil {
+		return filepath.Join(elem...)
+	}
+	return path.Join(elem...)
+}
+
+// relativeTo converts target, a path visited by walkDir(root), to one relative to root using the operating system's separator
+func (p *Processor) relativeTo(root string, target string) (string, error) {
+	if p.fsys == nil {
+		return filepath.Rel(root, target)
+	}
+
+	root = path.Clean(filepath.ToSlash(root))
+	switch {
+	case target == root:
+		return ".", nil
+	case root == ".":
+		return filepath.FromSlash(target), nil
+	default:
+		return filepath.FromSlash(strings.TrimPrefix(target, root+"/")), nil
+	}
+}
+
+// relative normalizes target, a path given to the Processor, to a clean path relative to the base directory (root).
+// Relative paths are already relative to the base, although they may begin with ./ or contain .. elements, while an
+// absolute path is converted to one relative to the base. A trailing separator, marking a directory, is retained.
+func (p *Processor) relative(target string) (string, error) {
+	slashed := filepath.ToSlash(target)
+	rel := filepath.Clean(target)
+	if filepath.IsAbs(target) || strings.HasPrefix(slashed, "/") {
+		// : paths within fsys are never absolute
+		if p.fsys != nil {
+			return "", fmt.Errorf("%w: %s is not within the file system", ErrOutsideBase, target)
+		}
+
+		base, err := filepath.Abs(p.root())
+		if err != nil {
+			return "", err
+		}
+		if rel, err = filepath.Rel(base, target); err != nil {
+			return "", fmt.Errorf("%w: %s is not within %s", ErrOutsideBase, target, base)
+		}
+	}
+
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s is not within %s", ErrOutsideBase, target, p.root())
+	}
+
+	if rel != "." && strings.HasSuffix(slashed, "/") {
+		rel += string(filepath.Separator)
+	}
+	return rel, nil
+}
+
+// scope provides the file system against which rules from an ignore file in the base directory are evaluated, so
+// that whether a path is a directory doesn't depend upon the process working directory.
+func (p *Processor) scope(base string) fs.FS {
+	if p.fsys != nil {
+		dir := path.Join(filepath.ToSlash(p.root()), filepath.ToSlash(base))
+		if dir == "." || dir == "" {
+			return p.fsys
+		}
+
+		sub, err := fs.Sub(p.fsys, dir)
+		if err != nil {
+			return p.fsys
+		}
+		return sub
+	}
+
+	return os.DirFS(filepath.Join(p.root(), base))
+}
@@ -0,0 +1,134 @@
+package ignore
+
+import (
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestWithFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore":         &fstest.MapFile{Data: []byte("*.log\nlogs/\ncache/\n")},
+		"custom.ignore":      &fstest.MapFile{Data: []byte("*.tmp\n")},
+		"logs/today.txt":     &fstest.MapFile{},
+		"cache":              &fstest.MapFile{},
+		"src/.gitignore":     &fstest.MapFile{Data: []byte("!debug.log\n")},
+		"src/debug.log":      &fstest.MapFile{},
+		"src/main.go":        &fstest.MapFile{},
+		"src/nested/app.log": &fstest.MapFile{},
+		"sub/.ignore":        &fstest.MapFile{Data: []byte("build/\n")},
+		"sub/build/out.txt":  &fstest.MapFile{},
+		"build/out.txt":      &fstest.MapFile{},
+	}
+
+	tests := []struct {
+		name       string
+		opts       []ProcessorOption
+		conditions []AllowTestCondition
+	}{
+		{
+			name: "reads default ignore file from fs",
+			opts: []ProcessorOption{WithFS(fsys)},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: false},
+				{File: "logs/", Allows: false},
+				{File: "logs/today.txt", Allows: false},
+				{File: "cache/", Allows: true}, // a file in fsys, so the directory rule doesn't apply
+				{File: "a.tmp", Allows: true},
+			},
+		},
+		{
+			name: "reads ignore file path from fs",
+			opts: []ProcessorOption{WithFS(fsys), WithIgnoreFilePath("custom.ignore")},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: true},
+				{File: "a.tmp", Allows: false},
+			},
+		},
+		{
+			name: "reads ignore file path from fs named before it",
+			opts: []ProcessorOption{WithIgnoreFilePath("custom.ignore"), WithFS(fsys)},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: true},
+				{File: "a.tmp", Allows: false},
+			},
+		},
+		{
+			name: "evaluates paths relative to the ignore file's directory",
+			opts: []ProcessorOption{WithFS(fsys), WithIgnoreFilePath("sub/.ignore")},
+			conditions: []AllowTestCondition{
+				{File: "build", Allows: false},
+				{File: "build/out.txt", Allows: false},
+				{File: "sub/build", Allows: true},
+				{File: "../build", Allows: false, WantErr: true},
+			},
+		},
+		{
+			name: "reads nested ignore files from fs",
+			opts: []ProcessorOption{WithFS(fsys), WithNestedIgnoreFiles(".")},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: false},
+				{File: "src/debug.log", Allows: true},
+				{File: "src/nested/app.log", Allows: false},
+			},
+		},
+		{
+			name: "reads nested ignore files from fs named before it",
+			opts: []ProcessorOption{WithNestedIgnoreFiles("src"), WithFS(fsys)},
+			conditions: []AllowTestCondition{
+				{File: "debug.log", Allows: true},
+				{File: "main.go", Allows: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := NewProcessor(tt.opts...)
+			if err != nil {
+				t.Fatalf("NewProcessor() error = %v", err)
+			}
+
+			assertConditions(t, processor, tt.conditions)
+		})
+	}
+}
+
+func TestWithFS_missingIgnoreFile(t *testing.T) {
+	_, err := NewProcessor(WithFS(fstest.MapFS{}), WithIgnoreFilePath("missing"))
+	if err == nil {
+		t.Errorf("NewProcessor() expected error for missing ignore file")
+	}
+}
+
+func TestProcessor_WalkDir_fs(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore":      &fstest.MapFile{Data: []byte("target/\n*.log\n")},
+		"debug.log":       &fstest.MapFile{},
+		"src/main.go":     &fstest.MapFile{},
+		"target/app":      &fstest.MapFile{},
+		"target/keep.txt": &fstest.MapFile{},
+	}
+
+	processor, err := NewProcessor(WithFS(fsys))
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	got := make([]string, 0)
+	err = processor.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		got = append(got, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkDir() error = %v", err)
+	}
+
+	want := []string{".", ".gitignore", "src", "src/main.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WalkDir() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,134 @@
+package ignore
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jimschubert/ignore/internal/gitconfig"
+)
+
+// WithGitExcludes is a functional option which layers git's additional exclude sources beneath the ignore file(s):
+// the user's core.excludesFile and $GIT_DIR/info/exclude. As for git, the excludes file is read from the global config
+// ($XDG_CONFIG_HOME/git/config and $HOME/.gitconfig) and then gitDir's local config, where a local value wins. It
+// defaults to $XDG_CONFIG_HOME/git/ignore (or $HOME/.config/git/ignore).
+//
+// As with git, info/exclude takes precedence over core.excludesFile, and the ignore file(s) take precedence over both.
+// Sources which do not exist are skipped.
+func WithGitExcludes(gitDir string) ProcessorOption {
+	return func(processor *Processor) error {
+		fileInfo, err := os.Stat(gitDir)
+		if err != nil {
+			return err
+		}
+
+		if !fileInfo.IsDir() {
+			return errors.New("git directory must be a directory")
+		}
+
+		excludesFile, err := gitExcludesFile(gitDir)
+		if err != nil {
+			return err
+		}
+
+		// lowest precedence first
+		for _, excludes := range []string{excludesFile, filepath.Join(gitDir, "info", "exclude")} {
+			if excludes == "" {
+				continue
+			}
+
+			if fileInfo, err := os.Stat(excludes); err != nil || fileInfo.IsDir() {
+				if err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return err
+				}
+				continue
+			}
+
+			processor.excludeFiles = append(processor.excludeFiles, excludes)
+		}
+
+		return nil
+	}
+}
+
+// gitExcludesFile determines the location of core.excludesFile, returning an empty string if it can't be determined
+func gitExcludesFile(gitDir string) (string, error) {
+	value := ""
+	for _, configFile := range gitConfigFiles(gitDir) {
+		configValue, found, err := gitConfigValue(configFile, "core", "excludesFile")
+		if err != nil {
+			return "", err
+		}
+
+		// : as with git, a value from a later config file overrides any earlier one
+		if found {
+			value = configValue
+		}
+	}
+
+	if value != "" {
+		return expandGitPath(value, filepath.Dir(filepath.Clean(gitDir))), nil
+	}
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "git", "ignore"), nil
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "git", "ignore"), nil
+	}
+
+	return "", nil
+}
+
+// gitConfigFiles lists the config files git reads for the repository at gitDir, lowest precedence first: the global
+// $XDG_CONFIG_HOME/git/config (or $HOME/.config/git/config) and $HOME/.gitconfig, then the local $GIT_DIR/config
+func gitConfigFiles(gitDir string) []string {
+	configFiles := make([]string, 0, 3)
+	home, homeErr := os.UserHomeDir()
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		configFiles = append(configFiles, filepath.Join(xdg, "git", "config"))
+	} else if homeErr == nil {
+		configFiles = append(configFiles, filepath.Join(home, ".config", "git", "config"))
+	}
+
+	if homeErr == nil {
+		configFiles = append(configFiles, filepath.Join(home, ".gitconfig"))
+	}
+
+	return append(configFiles, filepath.Join(gitDir, "config"))
+}
+
+// gitConfigValue reads the value of key within section from configFile, which is skipped if it doesn't exist
+func gitConfigValue(configFile string, section string, key string) (string, bool, error) {
+	config, err := os.Open(configFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(config)
+
+	return gitconfig.Value(config, section, key)
+}
+
+// expandGitPath expands a leading ~/ to the user's home directory, and resolves relative paths against workTree
+func expandGitPath(value string, workTree string) string {
+	if strings.HasPrefix(value, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, filepath.FromSlash(value[2:]))
+		}
+	}
+
+	value = filepath.FromSlash(value)
+	if filepath.IsAbs(value) {
+		return value
+	}
+
+	return filepath.Join(workTree, value)
+}
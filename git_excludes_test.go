@@ -0,0 +1,136 @@
+package ignore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jimschubert/ignore/test"
+)
+
+func TestWithGitExcludes(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		home       map[string]string
+		xdg        map[string]string
+		conditions []AllowTestCondition
+	}{
+		{
+			name: "core.excludesFile from local config",
+			files: map[string]string{
+				".git/config":       "[core]\n\tbare = false\n\texcludesFile = global_ignore\n",
+				".git/info/exclude": "!notes.bak\n*.local\n",
+				"global_ignore":     "*.bak\n*.swp\n",
+				".gitignore":        "!keep.swp\n",
+			},
+			conditions: []AllowTestCondition{
+				{File: "a.bak", Allows: false},
+				{File: "notes.bak", Allows: true},
+				{File: "a.swp", Allows: false},
+				{File: "keep.swp", Allows: true},
+				{File: "x.local", Allows: false},
+				{File: "other.txt", Allows: true},
+			},
+		},
+		{
+			name: "core.excludesFile from global config",
+			files: map[string]string{
+				".git/config": "[core]\n\tbare = false\n",
+				".gitignore":  "*.log\n",
+			},
+			home: map[string]string{
+				".gitconfig":    "[core]\n\texcludesFile = ~/global_ignore\n",
+				"global_ignore": "*.bak\n",
+			},
+			xdg: map[string]string{},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: false},
+				{File: "a.bak", Allows: false},
+				{File: "other.txt", Allows: true},
+			},
+		},
+		{
+			name: "core.excludesFile from XDG_CONFIG_HOME config",
+			files: map[string]string{
+				".git/config": "[core]\n\tbare = false\n",
+				".gitignore":  "",
+			},
+			home: map[string]string{
+				"xdg_ignore": "*.bak\n",
+			},
+			xdg: map[string]string{
+				"git/config": "[core]\n\texcludesFile = ~/xdg_ignore\n",
+				"git/ignore": "*.swp\n",
+			},
+			conditions: []AllowTestCondition{
+				{File: "a.bak", Allows: false},
+				{File: "a.swp", Allows: true},
+			},
+		},
+		{
+			name: "local core.excludesFile overrides global config",
+			files: map[string]string{
+				".git/config":  "[core]\n\texcludesFile = local_ignore\n",
+				"local_ignore": "*.swp\n",
+				".gitignore":   "",
+			},
+			home: map[string]string{
+				".gitconfig":    "[core]\n\texcludesFile = ~/global_ignore\n",
+				"global_ignore": "*.bak\n",
+			},
+			xdg: map[string]string{},
+			conditions: []AllowTestCondition{
+				{File: "a.bak", Allows: true},
+				{File: "a.swp", Allows: false},
+			},
+		},
+		{
+			name: "core.excludesFile defaults to XDG_CONFIG_HOME",
+			files: map[string]string{
+				".git/config": "[core]\n\tbare = false\n",
+				".gitignore":  "*.log\n",
+			},
+			xdg: map[string]string{
+				"git/ignore": "*.swp\n",
+			},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: false},
+				{File: "a.swp", Allows: false},
+				{File: "other.txt", Allows: true},
+			},
+		},
+		{
+			name: "missing sources are skipped",
+			files: map[string]string{
+				".git/":      "",
+				".gitignore": "*.log\n",
+			},
+			xdg: map[string]string{},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: false},
+				{File: "a.swp", Allows: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := test.Tree(t, tt.files)
+			// : the user's own global config must not affect the outcome
+			t.Setenv("HOME", test.Tree(t, tt.home))
+			if tt.xdg != nil {
+				t.Setenv("XDG_CONFIG_HOME", test.Tree(t, tt.xdg))
+			}
+
+			processor, err := NewProcessor(
+				WithGitignoreStrategy(),
+				WithIgnoreFilePath(filepath.Join(root, ".gitignore")),
+				WithGitExcludes(filepath.Join(root, ".git")),
+			)
+			if err != nil {
+				t.Fatalf("NewProcessor() error = %v", err)
+			}
+
+			assertConditions(t, processor, tt.conditions)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package ignore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jimschubert/ignore/test"
+)
+
+// gitignoreConformance describes whether git excludes path, given a .gitignore containing only pattern
+type gitignoreConformance struct {
+	pattern string
+	path    string
+	isDir   bool
+	ignored bool
+}
+
+// gitignoreConformanceCases are taken from the pattern format described by gitignore(5), and agree with the output of
+// git check-ignore
+var gitignoreConformanceCases = []gitignoreConformance{
+	// a single asterisk never matches a separator
+	{pattern: "*.log", path: "debug.log", ignored: true},
+	{pattern: "*.log", path: "a/b/debug.log", ignored: true},
+	{pattern: "*.log", path: "debug.log.txt", ignored: false},
+	{pattern: "a/*.txt", path: "a/b.txt", ignored: true},
+	{pattern: "a/*.txt", path: "a/b/c.txt", ignored: false},
+	{pattern: "a/*.txt", path: "b/a/c.txt", ignored: false},
+	{pattern: "foo*bar", path: "foo/bar", ignored: false},
+
+	// an extension may itself contain wildcards
+	{pattern: "*.tar*", path: "x.tar.gz", ignored: true},
+	{pattern: "*.tar*", path: "a/x.tar", ignored: true},
+	{pattern: "*.t?r", path: "x.tar", ignored: true},
+	{pattern: "*.tar.[gb]z", path: "x.tar.gz", ignored: true},
+
+	// a pattern naming an unknown character class never matches
+	{pattern: "[[:nope:]]z", path: "az", ignored: false},
+	{pattern: "[[:nope:]]z", path: "[:nope:]z", ignored: false},
+
+	// trailing spaces are ignored unless they are quoted with backslash
+	{pattern: "trail   ", path: "trail", ignored: true},
+	{pattern: `trail\ `, path: "trail ", ignored: true},
+	{pattern: `trail\ `, path: "trail", ignored: false},
+	{pattern: `trail\  `, path: "trail ", ignored: true},
+
+	// other consecutive asterisks are regular asterisks
+	{pattern: "foo**bar", path: "foobazbar", ignored: true},
+	{pattern: "foo**bar", path: "foo/baz/bar", ignored: false},
+
+	// a leading **/ matches in all directories
+	{pattern: "**/foo", path: "foo", ignored: true},
+	{pattern: "**/foo", path: "a/foo", ignored: true},
+	{pattern: "**/foo", path: "a/b/foo", ignored: true},
+	{pattern: "**/foo", path: "afoo", ignored: false},
+	{pattern: "**/foo/bar", path: "foo/bar", ignored: true},
+	{pattern: "**/foo/bar", path: "a/b/foo/bar", ignored: true},
+	{pattern: "**/foo/bar", path: "foo/x/bar", ignored: false},
+	{pattern: "**/foo/bar", path: "bar", ignored: false},
+	{pattern: "**/*.js", path: "a/b/c.js", ignored: true},
+	{pattern: "**/*.js", path: "c.js", ignored: true},
+
+	// a trailing /** matches everything inside
+	{pattern: "abc/**", path: "abc/x", ignored: true},
+	{pattern: "abc/**", path: "abc/x/y", ignored: true},
+	{pattern: "abc/**", path: "abc", isDir: true, ignored: false},
+	{pattern: "abc/**", path: "x/abc/y", ignored: false},
+
+	// /**/ matches zero or more directories
+	{pattern: "a/**/b", path: "a/b", ignored: true},
+	{pattern: "a/**/b", path: "a/x/b", ignored: true},
+	{pattern: "a/**/b", path: "a/x/y/b", ignored: true},
+	{pattern: "a/**/b", path: "a/xb", ignored: false},
+	{pattern: "a/**/b", path: "ab", ignored: false},
+	{pattern: "doc/**/*.pdf", path: "doc/a.pdf", ignored: true},
+	{pattern: "doc/**/*.pdf", path: "doc/x/y/a.pdf", ignored: true},
+	{pattern: "doc/**/*.pdf", path: "a.pdf", ignored: false},
+
+	// ** alone matches everything
+	{pattern: "**", path: "a", ignored: true},
+	{pattern: "**", path: "a/b/c", ignored: true},
+
+	// directory patterns
+	{pattern: "**/build/", path: "build", isDir: true, ignored: true},
+	{pattern: "**/build/", path: "a/build", isDir: true, ignored: true},
+	{pattern: "**/build/", path: "a/build/out.txt", ignored: true},
+	{pattern: "**/build/", path: "a/buildx", isDir: true, ignored: false},
+	{pattern: "**/build/", path: "build", ignored: false},
+	{pattern: "foo/bar/", path: "foo/bar", isDir: true, ignored: true},
+	{pattern: "foo/bar/", path: "foo/bar/x", ignored: true},
+	{pattern: "foo/bar/", path: "foo/barbaz", isDir: true, ignored: false},
+	{pattern: "foo/**/", path: "foo/a/b", isDir: true, ignored: true},
+	{pattern: "/build/", path: "build", isDir: true, ignored: true},
+	{pattern: "/build/", path: "a/build", isDir: true, ignored: false},
+
+	// a separator at the beginning or middle of a pattern anchors it to the ignore file's directory
+	{pattern: "docs/*.md", path: "docs/a.md", ignored: true},
+	{pattern: "docs/*.md", path: "a/docs/b.md", ignored: false},
+	{pattern: "docs/*.md", path: "docs/x/a.md", ignored: false},
+	{pattern: "/docs/*.md", path: "docs/a.md", ignored: true},
+	{pattern: "/*.md", path: "a/b.md", ignored: false},
+	{pattern: "/foo", path: "foo", isDir: true, ignored: true},
+	{pattern: "/foo", path: "a/foo", ignored: false},
+
+	// otherwise a pattern matches at any level, files and directories alike
+	{pattern: "*.md", path: "a/b.md", ignored: true},
+	{pattern: "*", path: "a/b", ignored: true},
+	{pattern: "foo", path: "a/foo", isDir: true, ignored: true},
+	{pattern: "foo", path: "a/foo/x", ignored: true},
+	{pattern: "*.d", path: "conf.d", isDir: true, ignored: true},
+}
+
+func TestGitignoreConformance(t *testing.T) {
+	for _, tt := range gitignoreConformanceCases {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			root := test.Tree(t, map[string]string{".gitignore": tt.pattern + "\n"})
+			processor, err := NewProcessor(
+				WithGitignoreStrategy(),
+				WithIgnoreFilePath(filepath.Join(root, ".gitignore")),
+			)
+			if err != nil {
+				t.Fatalf("NewProcessor() error = %v", err)
+			}
+
+			allowed, err := processor.AllowsPath(filepath.FromSlash(tt.path), tt.isDir)
+			if err != nil {
+				t.Fatalf("AllowsPath() error = %v", err)
+			}
+			if allowed == tt.ignored {
+				t.Errorf("AllowsPath(%q, %v) with pattern %q: allowed = %v, want %v", tt.path, tt.isDir, tt.pattern, allowed, !tt.ignored)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package gitconfig
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Value reads the value of key within section from git config formatted input. Section and key names are
+// case-insensitive, as they are for git. Subsections (e.g. [remote "origin"]) are not matched by section.
+// When a key is defined more than once, the last definition wins.
+func Value(reader io.Reader, section string, key string) (string, bool, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Split(bufio.ScanLines)
+
+	current := ""
+	value := ""
+	found := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			end := strings.Index(line, "]")
+			if end < 0 {
+				current = ""
+				continue
+			}
+
+			current = strings.ToLower(strings.TrimSpace(line[1:end]))
+			// allow for "key = value" following a section header on the same line
+			line = strings.TrimSpace(line[end+1:])
+			if line == "" {
+				continue
+			}
+		}
+
+		if current != strings.ToLower(section) {
+			continue
+		}
+
+		name, raw, hasValue := strings.Cut(line, "=")
+		if !strings.EqualFold(strings.TrimSpace(name), key) {
+			continue
+		}
+
+		found = true
+		if !hasValue {
+			// a key without a value is boolean true in git config
+			value = "true"
+			continue
+		}
+		value = unquote(raw)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", false, err
+	}
+
+	return value, found, nil
+}
+
+// unquote removes surrounding whitespace, trailing comments, and double quotes from a raw config value
+func unquote(raw string) string {
+	var b strings.Builder
+	quoted := false
+	escaped := false
+	for _, r := range strings.TrimSpace(raw) {
+		switch {
+		case escaped:
+			switch r {
+			case 't':
+				b.WriteRune('\t')
+			case 'n':
+				b.WriteRune('\n')
+			default:
+				b.WriteRune(r)
+			}
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && (r == '#' || r == ';'):
+			return strings.TrimSpace(b.String())
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if quoted {
+		return b.String()
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,72 @@
+package gitconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValue(t *testing.T) {
+	type args struct {
+		config  string
+		section string
+		key     string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      string
+		wantFound bool
+	}{
+		{
+			name:      "simple value",
+			args:      args{config: "[core]\n\texcludesFile = ~/.gitignore_global\n", section: "core", key: "excludesFile"},
+			want:      "~/.gitignore_global",
+			wantFound: true,
+		},
+		{
+			name:      "case insensitive names",
+			args:      args{config: "[Core]\n\texcludesfile = /tmp/ignore\n", section: "core", key: "excludesFile"},
+			want:      "/tmp/ignore",
+			wantFound: true,
+		},
+		{
+			name:      "quoted value with comment",
+			args:      args{config: "[core]\n\texcludesFile = \"/tmp/my ignore\" # global\n", section: "core", key: "excludesFile"},
+			want:      "/tmp/my ignore",
+			wantFound: true,
+		},
+		{
+			name:      "last definition wins",
+			args:      args{config: "[core]\nexcludesFile = a\n[user]\nname = me\n[core]\nexcludesFile = b\n", section: "core", key: "excludesFile"},
+			want:      "b",
+			wantFound: true,
+		},
+		{
+			name:      "subsection is not section",
+			args:      args{config: "[core \"sub\"]\nexcludesFile = a\n", section: "core", key: "excludesFile"},
+			want:      "",
+			wantFound: false,
+		},
+		{
+			name:      "missing key",
+			args:      args{config: "; comment\n[core]\nbare = true\n", section: "core", key: "excludesFile"},
+			want:      "",
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found, err := Value(strings.NewReader(tt.args.config), tt.args.section, tt.args.key)
+			if err != nil {
+				t.Errorf("Value() error = %v", err)
+				return
+			}
+			if found != tt.wantFound {
+				t.Errorf("Value() found = %v, want %v", found, tt.wantFound)
+			}
+			if got != tt.want {
+				t.Errorf("Value() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
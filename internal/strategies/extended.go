@@ -0,0 +1,79 @@
+package strategies
+
+import (
+	"github.com/jimschubert/ignore/parser"
+	"github.com/jimschubert/ignore/strategy"
+)
+
+// extendedStrategy is an implementation of Strategy and Mutable, which also provides the optional capabilities
+// defined by the strategy package. Zero values leave the Processor's default behavior in place.
+type extendedStrategy struct {
+	fullPath    string
+	parser      parser.Parser
+	ruleBuilder strategy.RuleBuilder
+	// fallbackNames are read in place of a missing ignore file
+	fallbackNames []string
+	// presets, when set, contributes definitions beyond those of ignore files
+	presets func(open strategy.OpenFunc) (strategy.Presets, error)
+	// firstMatch causes the first matching rule to decide a path's outcome, rather than the last
+	firstMatch bool
+}
+
+// RuleBuilder …
+func (e *extendedStrategy) RuleBuilder() strategy.RuleBuilder {
+	return e.ruleBuilder
+}
+
+// DefinitionPath …
+func (e *extendedStrategy) DefinitionPath() string {
+	return e.fullPath
+}
+
+// Parser …
+func (e *extendedStrategy) Parser() parser.Parser {
+	return e.parser
+}
+
+// SetDefinitionPath …
+func (e *extendedStrategy) SetDefinitionPath(path string) error {
+	e.fullPath = path
+	return nil
+}
+
+// SetParser …
+func (e *extendedStrategy) SetParser(parser parser.Parser) error {
+	e.parser = parser
+	return nil
+}
+
+// SetRuleBuilder …
+func (e *extendedStrategy) SetRuleBuilder(builder strategy.RuleBuilder) error {
+	e.ruleBuilder = builder
+	return nil
+}
+
+// FallbackNames …
+func (e *extendedStrategy) FallbackNames() []string {
+	return e.fallbackNames
+}
+
+// Presets …
+func (e *extendedStrategy) Presets(open strategy.OpenFunc) (strategy.Presets, error) {
+	if e.presets == nil {
+		return strategy.Presets{}, nil
+	}
+	return e.presets(open)
+}
+
+// FirstMatchWins …
+func (e *extendedStrategy) FirstMatchWins() bool {
+	return e.firstMatch
+}
+
+// Forces compilation error if interface contract changes
+var (
+	_ Mutable             = &extendedStrategy{}
+	_ strategy.Fallback   = &extendedStrategy{}
+	_ strategy.Preset     = &extendedStrategy{}
+	_ strategy.Precedence = &extendedStrategy{}
+)
@@ -0,0 +1,67 @@
+package strategies
+
+import (
+	"strings"
+
+	"github.com/jimschubert/ignore/internal/util"
+	"github.com/jimschubert/ignore/parser"
+	"github.com/jimschubert/ignore/rules"
+	"github.com/jimschubert/ignore/strategy"
+)
+
+// cvsDefaults are ignored by CVS in every directory, unless cleared by a lone !
+var cvsDefaults = []string{
+	"RCS SCCS CVS CVS.adm RCSLOG cvslog.* tags TAGS .make.state .nse_depinfo",
+	"*~ #* .#* ,* _$* *$ *.old *.bak *.BAK *.orig *.rej .del-*",
+	"*.a *.olb *.o *.obj *.so *.exe *.Z *.elc *.ln core",
+}
+
+// svnDefaults are the default global-ignores of Subversion's runtime configuration
+var svnDefaults = []string{
+	parser.SvnGlobalIgnores,
+	"*.o", "*.lo", "*.la", "*.al", ".libs", "*.so", "*.so.[0-9]*", "*.a", "*.pyc", "*.pyo", "__pycache__",
+	"*.rej", "*~", "#*#", ".#*", ".*.swp", ".DS_Store", "[Tt]humbs.db",
+}
+
+// nameRuleBuilder builds name rules, and empty rules for lines which only affect the parser
+var nameRuleBuilder = strategy.BuildRulesFrom(func(parts []parser.TokenValue) (rules.Rule, error) {
+	if len(parts) == 1 && parts[0].Token == parser.Syntax {
+		return rules.NewEmptyRule(util.StringValue(parts[0].Line), parts)
+	}
+
+	return rules.NewNameRule(util.StringValue(parts[0].Line), parts)
+})
+
+// CvsignoreStrategy provides a strategy for parsing and building rules from .cvsignore files, whose patterns apply
+// to the entries of their own directory, along with the default ignore list of CVS.
+func CvsignoreStrategy() strategy.Strategy {
+	return &extendedStrategy{
+		fullPath:    ".cvsignore",
+		parser:      parser.NewCvsignoreParser(),
+		ruleBuilder: nameRuleBuilder,
+		presets: func(_ strategy.OpenFunc) (strategy.Presets, error) {
+			return strategy.Presets{
+				Source:         "cvs",
+				Before:         strings.Join(cvsDefaults, "\n"),
+				EveryDirectory: true,
+			}, nil
+		},
+	}
+}
+
+// SvnignoreStrategy provides a strategy for parsing and building rules from the values of Subversion's svn:ignore
+// and svn:global-ignores properties, stored in a file (.svnignore) within each directory, along with the default
+// global-ignores of Subversion.
+func SvnignoreStrategy() strategy.Strategy {
+	return &extendedStrategy{
+		fullPath:    ".svnignore",
+		parser:      parser.NewSvnignoreParser(),
+		ruleBuilder: nameRuleBuilder,
+		presets: func(_ strategy.OpenFunc) (strategy.Presets, error) {
+			return strategy.Presets{
+				Source: "svn",
+				Before: strings.Join(svnDefaults, "\n"),
+			}, nil
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package strategies
+
+import (
+	"strings"
+
+	"github.com/jimschubert/ignore/internal/util"
+	"github.com/jimschubert/ignore/parser"
+	"github.com/jimschubert/ignore/rules"
+	"github.com/jimschubert/ignore/strategy"
+)
+
+// manifestDefaults select the files of an sdist ahead of MANIFEST.in: nothing, other than the files setuptools
+// always includes
+var manifestDefaults = []string{
+	"global-exclude *",
+	"include README README.txt README.rst README.md setup.py setup.cfg pyproject.toml MANIFEST.in",
+	"include LICEN[CS]E* COPYING* NOTICE* AUTHORS*",
+}
+
+// manifestPruned are removed by setuptools after applying MANIFEST.in
+var manifestPruned = []string{
+	"prune build",
+	"global-exclude RCS/** CVS/** .svn/** .hg/** .git/** .bzr/** _darcs/**",
+}
+
+// manifestPresets excludes everything by default, so that only the files selected by MANIFEST.in commands (along
+// with those setuptools always includes) are allowed
+func manifestPresets(_ strategy.OpenFunc) (strategy.Presets, error) {
+	return strategy.Presets{
+		Source: "sdist",
+		Before: strings.Join(manifestDefaults, "\n"),
+		After:  strings.Join(manifestPruned, "\n"),
+	}, nil
+}
+
+// ManifestStrategy provides a strategy for parsing and building rules from Python's MANIFEST.in, which selects the
+// files of an sdist. Paths are excluded unless selected by a command, and the last matching command wins.
+func ManifestStrategy() strategy.Strategy {
+	return &extendedStrategy{
+		fullPath: "MANIFEST.in",
+		parser:   parser.NewManifestParser(),
+		ruleBuilder: strategy.BuildRulesFrom(func(parts []parser.TokenValue) (rules.Rule, error) {
+			if parts[0].Token == parser.Comment {
+				return rules.NewEmptyRule(util.StringValue(parts[0].Line), parts)
+			}
+
+			return rules.NewManifestRule(util.StringValue(parts[0].Line), parts)
+		}),
+		presets: manifestPresets,
+	}
+}
@@ -66,10 +66,68 @@ func AsMutable(strategy strategy.Strategy) (Mutable, error) {
 		d := strategy.DefinitionPath()
 		p := strategy.Parser()
 		b := strategy.RuleBuilder()
-		return &mutableStrategy{
+		m := mutableStrategy{
 			fullPath:    &d,
 			parser:      &p,
 			ruleBuilder: &b,
-		}, nil
+		}
+		if hasOptionalCapabilities(strategy) {
+			return &wrappedStrategy{mutableStrategy: m, wrapped: strategy}, nil
+		}
+		return &m, nil
 	}
 }
+
+// wrappedStrategy is a Mutable copy of a strategy which provides any of the optional capabilities defined by the
+// strategy package, forwarding those capabilities to the original
+type wrappedStrategy struct {
+	mutableStrategy
+	wrapped strategy.Strategy
+}
+
+// FallbackNames …
+func (w *wrappedStrategy) FallbackNames() []string {
+	if fallback, ok := w.wrapped.(strategy.Fallback); ok {
+		return fallback.FallbackNames()
+	}
+	return nil
+}
+
+// Presets …
+func (w *wrappedStrategy) Presets(open strategy.OpenFunc) (strategy.Presets, error) {
+	if preset, ok := w.wrapped.(strategy.Preset); ok {
+		return preset.Presets(open)
+	}
+	return strategy.Presets{}, nil
+}
+
+// FirstMatchWins …
+func (w *wrappedStrategy) FirstMatchWins() bool {
+	if precedence, ok := w.wrapped.(strategy.Precedence); ok {
+		return precedence.FirstMatchWins()
+	}
+	return false
+}
+
+// hasOptionalCapabilities determines whether s implements strategy.Fallback, strategy.Preset or strategy.Precedence
+func hasOptionalCapabilities(s strategy.Strategy) bool {
+	switch s.(type) {
+	case strategy.Fallback, strategy.Preset, strategy.Precedence:
+		return true
+	}
+	return false
+}
+
+// HasFallbacks determines whether s reads other ignore files in place of a missing one (see strategy.Fallback)
+func HasFallbacks(s strategy.Strategy) bool {
+	fallback, ok := s.(strategy.Fallback)
+	return ok && len(fallback.FallbackNames()) > 0
+}
+
+// Forces compilation error if interface contract changes
+var (
+	_ Mutable             = &wrappedStrategy{}
+	_ strategy.Fallback   = &wrappedStrategy{}
+	_ strategy.Preset     = &wrappedStrategy{}
+	_ strategy.Precedence = &wrappedStrategy{}
+)
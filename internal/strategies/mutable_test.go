@@ -15,6 +15,8 @@ func TestAsMutable(t *testing.T) {
 		parser:   parser.NewGitignoreParser(),
 	}
 
+	simplePrecedence := precedenceStrategy{simpleFull}
+
 	type args struct {
 		strategy strategy.Strategy
 	}
@@ -30,6 +32,10 @@ func TestAsMutable(t *testing.T) {
 		{name: "can make gitignore struct mutable", args: args{strategy: simpleFull}, want: &mutableStrategy{
 			fullPath: &simpleFull.fullPath, parser: &simpleFull.parser, ruleBuilder: &simpleFull.ruleBuilder,
 		}, wantErr: false},
+		{name: "retains optional capabilities", args: args{strategy: simplePrecedence}, want: &wrappedStrategy{
+			mutableStrategy: mutableStrategy{fullPath: &simpleFull.fullPath, parser: &simpleFull.parser, ruleBuilder: &simpleFull.ruleBuilder},
+			wrapped:         simplePrecedence,
+		}, wantErr: false},
 		{name: "fail if immutable", args: args{strategy: immutableStrategy{simpleFull}}, want: nil, wantErr: true},
 	}
 	for _, tt := range tests {
@@ -45,3 +51,12 @@ func TestAsMutable(t *testing.T) {
 		})
 	}
 }
+
+// precedenceStrategy is a strategy which isn't Mutable, but provides strategy.Precedence
+type precedenceStrategy struct {
+	simpleStrategy
+}
+
+func (p precedenceStrategy) FirstMatchWins() bool {
+	return true
+}
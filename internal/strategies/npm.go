@@ -0,0 +1,160 @@
+package strategies
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jimschubert/ignore/strategy"
+)
+
+// npmAlwaysExcluded are never packed by npm, regardless of any ignore file or "files" list
+var npmAlwaysExcluded = []string{
+	".npmignore",
+	".gitignore",
+	".git/",
+	".svn/",
+	".hg/",
+	"CVS/",
+	"node_modules/",
+	"/.lock-wscript",
+	"/.wafpickle-*",
+	"/build/config.gypi",
+	"npm-debug.log",
+	".npmrc",
+	".*.swp",
+	".DS_Store",
+	"._*",
+	"*.orig",
+	"/package-lock.json",
+	"/yarn.lock",
+	"/pnpm-lock.yaml",
+	"/archived-packages/",
+}
+
+// npmAlwaysIncluded are always packed by npm, matched case-insensitively where npm does
+var npmAlwaysIncluded = []string{
+	"!/package.json",
+	"!/[Rr][Ee][Aa][Dd][Mm][Ee]*",
+	"!/[Cc][Oo][Pp][Yy][Ii][Nn][Gg]*",
+	"!/[Ll][Ii][Cc][Ee][Nn][Ss][Ee]*",
+	"!/[Ll][Ii][Cc][Ee][Nn][Cc][Ee]*",
+}
+
+// packageManifest is the subset of package.json which affects the contents of a package
+type packageManifest struct {
+	Files []string        `json:"files"`
+	Main  string          `json:"main"`
+	Bin   json.RawMessage `json:"bin"`
+}
+
+// npmPresets reads package.json, when present, to determine the files npm always packs. A "files" list in package.json
+// acts as an allowlist which replaces the root ignore file.
+func npmPresets(open strategy.OpenFunc) (strategy.Presets, error) {
+	presets := strategy.Presets{Source: "package.json"}
+
+	manifest, err := readPackageManifest(open)
+	if err != nil {
+		return presets, err
+	}
+
+	included := append([]string{}, npmAlwaysIncluded...)
+	for _, entry := range manifest.entryPoints() {
+		included = append(included, "!/"+entry)
+	}
+
+	if manifest.Files != nil {
+		presets.Before = strings.Join(allowlist(manifest.Files), "\n")
+		presets.ReplacesRoot = true
+	}
+	presets.After = strings.Join(append(append([]string{}, npmAlwaysExcluded...), included...), "\n")
+	return presets, nil
+}
+
+// readPackageManifest reads package.json, treating a missing file as an empty manifest
+func readPackageManifest(open strategy.OpenFunc) (packageManifest, error) {
+	manifest := packageManifest{}
+	file, err := open("package.json")
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return manifest, nil
+		}
+		return manifest, err
+	}
+	defer func(file io.ReadCloser) {
+		_ = file.Close()
+	}(file)
+
+	if err := json.NewDecoder(file).Decode(&manifest); err != nil {
+		return manifest, err
+	}
+	return manifest, nil
+}
+
+// entryPoints lists the "main" and "bin" files of a package, which npm always packs
+func (m packageManifest) entryPoints() []string {
+	entries := make([]string, 0)
+	if m.Main != "" {
+		entries = append(entries, cleanPackagePath(m.Main))
+	}
+
+	var single string
+	var named map[string]string
+	if json.Unmarshal(m.Bin, &single) == nil && single != "" {
+		entries = append(entries, cleanPackagePath(single))
+	} else if json.Unmarshal(m.Bin, &named) == nil {
+		bins := make([]string, 0, len(named))
+		for _, bin := range named {
+			bins = append(bins, cleanPackagePath(bin))
+		}
+		sort.Strings(bins)
+		entries = append(entries, bins...)
+	}
+	return entries
+}
+
+// allowlist converts the "files" list of package.json into gitignore definitions which exclude everything else.
+// Each entry re-includes its parent directories (but not their other contents), itself, and (for a directory) its
+// contents.
+func allowlist(files []string) []string {
+	definitions := []string{"**"}
+	seen := make(map[string]bool)
+	for _, file := range files {
+		entry := cleanPackagePath(file)
+		if entry == "" || entry == "." {
+			continue
+		}
+
+		for dir := path.Dir(entry); dir != "."; dir = path.Dir(dir) {
+			if parent := "!/" + dir; !seen[parent] {
+				seen[parent] = true
+				definitions = append(definitions, parent)
+			}
+		}
+		definitions = append(definitions, "!/"+entry, "!/"+entry+"/**")
+	}
+	return definitions
+}
+
+// cleanPackagePath normalizes a path from package.json to one relative to the package root
+func cleanPackagePath(p string) string {
+	p = path.Clean(strings.TrimPrefix(p, "./"))
+	return strings.TrimSuffix(strings.TrimPrefix(p, "/"), "/")
+}
+
+// NpmignoreStrategy provides a strategy for parsing and building rules from .npmignore files, using npm's
+// fallback to .gitignore and the files npm always includes or excludes when packing.
+func NpmignoreStrategy() strategy.Strategy {
+	git := GitignoreStrategy()
+	return &extendedStrategy{
+		fullPath:      ".npmignore",
+		parser:        git.Parser(),
+		ruleBuilder:   git.RuleBuilder(),
+		fallbackNames: []string{".gitignore"},
+		presets:       npmPresets,
+	}
+}
@@ -0,0 +1,67 @@
+package strategies
+
+import (
+	"io"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jimschubert/ignore/strategy"
+)
+
+func openManifest(contents string) strategy.OpenFunc {
+	return func(name string) (io.ReadCloser, error) {
+		if name != "package.json" || contents == "" {
+			return nil, fs.ErrNotExist
+		}
+		return io.NopCloser(strings.NewReader(contents)), nil
+	}
+}
+
+func TestNpmStrategy_Presets(t *testing.T) {
+	tests := []struct {
+		name         string
+		manifest     string
+		wantBefore   string
+		wantIncluded []string
+		wantReplaces bool
+		wantErr      bool
+	}{
+		{name: "without package.json", manifest: ""},
+		{name: "with entry points", manifest: `{"main": "./lib/index.js", "bin": "bin/cli.js"}`, wantIncluded: []string{"!/lib/index.js", "!/bin/cli.js"}},
+		{name: "with named bins", manifest: `{"bin": {"b": "b.js", "a": "a.js"}}`, wantIncluded: []string{"!/a.js", "!/b.js"}},
+		{
+			name:         "with files allowlist",
+			manifest:     `{"files": ["dist/", "types/*.d.ts"]}`,
+			wantBefore:   "**\n!/dist\n!/dist/**\n!/types\n!/types/*.d.ts\n!/types/*.d.ts/**",
+			wantReplaces: true,
+		},
+		{name: "with invalid package.json", manifest: `{`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NpmignoreStrategy().(strategy.Preset)
+			got, err := s.Presets(openManifest(tt.manifest))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Presets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.Before != tt.wantBefore {
+				t.Errorf("Presets() Before = %q, want %q", got.Before, tt.wantBefore)
+			}
+			if got.ReplacesRoot != tt.wantReplaces {
+				t.Errorf("Presets() ReplacesRoot = %v, want %v", got.ReplacesRoot, tt.wantReplaces)
+			}
+
+			after := strings.Split(got.After, "\n")
+			want := append(append(append([]string{}, npmAlwaysExcluded...), npmAlwaysIncluded...), tt.wantIncluded...)
+			if !reflect.DeepEqual(after, want) {
+				t.Errorf("Presets() After = %v, want %v", after, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package strategies
+
+import "github.com/jimschubert/ignore/strategy"
+
+// registers the built-in strategies, so they may be selected by name or ignore file name
+func init() {
+	strategy.MustRegister("gitignore", GitignoreStrategy, ".gitignore")
+	strategy.MustRegister("dockerignore", DockerignoreStrategy, ".dockerignore")
+	strategy.MustRegister("gcloudignore", GcloudignoreStrategy, ".gcloudignore")
+	strategy.MustRegister("hgignore", HgignoreStrategy, ".hgignore")
+	strategy.MustRegister("stignore", StignoreStrategy, ".stignore")
+	strategy.MustRegister("rsync-filter", RsyncFilterStrategy, ".rsync-filter")
+	strategy.MustRegister("manifest", ManifestStrategy, "MANIFEST.in")
+	strategy.MustRegister("cvsignore", CvsignoreStrategy, ".cvsignore")
+	strategy.MustRegister("svnignore", SvnignoreStrategy, ".svnignore")
+	strategy.MustRegister("npmignore", NpmignoreStrategy, ".npmignore")
+}
@@ -27,26 +27,106 @@ func GitignoreStrategy() strategy.Strategy {
 			case 0:
 				return rules.NewEmptyRule(util.StringValue(parts[0].Line), parts)
 			case 1:
-				part := parts[0]
-				if part.Token == parser.MatchAny {
-					return rules.NewRootedFileRule(util.StringValue(parts[0].Line), parts)
-				}
-
 				return rules.NewFileRule(util.StringValue(parts[0].Line), parts)
 			}
 
-			head := parts[0]
 			tail := parts[len(parts)-1]
-
 			if tail.Token == parser.DirectoryMarker {
 				return rules.NewDirectoryRule(util.StringValue(parts[0].Line), parts)
 			}
 
-			if head.Token == parser.RootedMarker {
-				return rules.NewRootedFileRule(util.StringValue(parts[0].Line), parts)
+			// : a separator at the beginning or middle of the pattern anchors it to the directory of the .gitignore file,
+			// otherwise the pattern may match at any depth
+			for _, part := range parts {
+				if part.Token == parser.RootedMarker || part.Token == parser.PathDelim {
+					return rules.NewRootedFileRule(util.StringValue(parts[0].Line), parts)
+				}
 			}
 
 			return rules.NewFileRule(util.StringValue(parts[0].Line), parts)
 		}),
 	}
 }
+
+// DockerignoreStrategy provides a strategy for parsing and building rules from .dockerignore files.
+func DockerignoreStrategy() strategy.Strategy {
+	return simpleStrategy{
+		fullPath: ".dockerignore",
+		parser:   parser.NewDockerignoreParser(),
+		ruleBuilder: strategy.BuildRulesFrom(func(parts []parser.TokenValue) (rules.Rule, error) {
+			if parts[0].Token == parser.Comment {
+				return rules.NewEmptyRule(util.StringValue(parts[0].Line), parts)
+			}
+
+			return rules.NewDockerRule(util.StringValue(parts[0].Line), parts)
+		}),
+	}
+}
+
+// HgignoreStrategy provides a strategy for parsing and building rules from Mercurial's .hgignore files.
+func HgignoreStrategy() strategy.Strategy {
+	return simpleStrategy{
+		fullPath: ".hgignore",
+		parser:   parser.NewHgignoreParser(),
+		ruleBuilder: strategy.BuildRulesFrom(func(parts []parser.TokenValue) (rules.Rule, error) {
+			// comments and "syntax:" lines only affect the parser
+			if parts[0].Token == parser.Comment || len(parts) == 1 {
+				return rules.NewEmptyRule(util.StringValue(parts[0].Line), parts)
+			}
+
+			return rules.NewHgRule(util.StringValue(parts[0].Line), parts)
+		}),
+	}
+}
+
+// GcloudignoreStrategy provides a strategy for parsing and building rules from .gcloudignore files. Rules follow
+// gitignore syntax; include directives are resolved by the caller, so they build an empty rule.
+func GcloudignoreStrategy() strategy.Strategy {
+	git := GitignoreStrategy()
+	return simpleStrategy{
+		fullPath: ".gcloudignore",
+		parser:   parser.NewGcloudignoreParser(),
+		ruleBuilder: strategy.BuildRulesFrom(func(parts []parser.TokenValue) (rules.Rule, error) {
+			if len(parts) > 0 && parts[0].Token == parser.Include {
+				return rules.NewEmptyRule(util.StringValue(parts[0].Line), parts)
+			}
+
+			return git.RuleBuilder().RuleFor(parts)
+		}),
+	}
+}
+
+// StignoreStrategy provides a strategy for parsing and building rules from Syncthing's .stignore files, in which the
+// first matching rule decides a path's outcome.
+func StignoreStrategy() strategy.Strategy {
+	return &extendedStrategy{
+		fullPath: ".stignore",
+		parser:   parser.NewStignoreParser(),
+		ruleBuilder: strategy.BuildRulesFrom(func(parts []parser.TokenValue) (rules.Rule, error) {
+			if parts[0].Token == parser.Comment || parts[0].Token == parser.Include {
+				return rules.NewEmptyRule(util.StringValue(parts[0].Line), parts)
+			}
+
+			return rules.NewSyncthingRule(util.StringValue(parts[0].Line), parts)
+		}),
+		firstMatch: true,
+	}
+}
+
+// RsyncFilterStrategy provides a strategy for parsing and building rules from rsync filter files (e.g. .rsync-filter),
+// in which the first matching rule decides a path's outcome.
+func RsyncFilterStrategy() strategy.Strategy {
+	return &extendedStrategy{
+		fullPath: ".rsync-filter",
+		parser:   parser.NewRsyncFilterParser(),
+		ruleBuilder: strategy.BuildRulesFrom(func(parts []parser.TokenValue) (rules.Rule, error) {
+			if parts[0].Token != parser.Filter {
+				// comments and directives are resolved by the caller
+				return rules.NewEmptyRule(util.StringValue(parts[0].Line), parts)
+			}
+
+			return rules.NewRsyncRule(util.StringValue(parts[0].Line), parts)
+		}),
+		firstMatch: true,
+	}
+}
@@ -0,0 +1,70 @@
+package ignore
+
+import (
+	"github.com/jimschubert/ignore/rules"
+)
+
+// Match describes how the Processor decided whether a path is allowed, similar to `git check-ignore -v`
+type Match struct {
+	// Path is the evaluated path, as it was given
+	Path string
+	// Allowed is the Processor's decision for Path
+	Allowed bool
+	// Rule is the rule which decided the outcome, or nil if no rule matched Path. This may be the rule which
+	// excluded a parent directory of Path.
+	Rule rules.Rule
+	// Pattern is the raw definition of Rule
+	Pattern string
+	// Source is the location of the ignore file defining Rule
+	Source string
+	// Line is the 1-based line number of Rule's definition within Source
+	Line int
+	// Negated is true when Rule is a negated (re-including) pattern
+	Negated bool
+}
+
+// Matched determines whether any rule matched the evaluated path
+func (m Match) Matched() bool {
+	return m.Rule != nil
+}
+
+// Explain evaluates path like AllowsFile, but describes the rule which decided the outcome
+func (p *Processor) Explain(path string) (Match, error) {
+	if err := p.processIgnoreFile(); err != nil {
+		return Match{Path: path, Allowed: true}, err
+	}
+
+	return p.explain(path, nil)
+}
+
+// ExplainPath evaluates path like AllowsPath, but describes the rule which decided the outcome
+func (p *Processor) ExplainPath(path string, isDir bool) (Match, error) {
+	if err := p.processIgnoreFile(); err != nil {
+		return Match{Path: path, Allowed: true}, err
+	}
+
+	return p.explain(path, &isDir)
+}
+
+func (p *Processor) explain(path string, isDir *bool) (Match, error) {
+	relativePath, err := p.relative(path)
+	if err != nil {
+		return Match{Path: path}, err
+	}
+
+	d, err := p.decide(relativePath, isDir)
+	if err != nil {
+		return Match{Path: path}, err
+	}
+
+	result := Match{Path: path, Allowed: allowedBy(d.op)}
+	if d.rule != nil {
+		result.Rule = d.rule.Rule
+		result.Pattern = d.rule.Raw()
+		result.Source = d.rule.definedIn(d.set.source)
+		result.Line = d.rule.line
+		result.Negated = d.rule.Negated()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package ignore
+
+import (
+	"testing"
+
+	"github.com/jimschubert/ignore/test"
+)
+
+func TestProcessor_Explain(t *testing.T) {
+	tests := []struct {
+		name       string
+		ignoreFile string
+		path       string
+		want       Match
+	}{
+		{
+			name:       "last matching rule",
+			ignoreFile: "last_match_wins",
+			path:       "keep.log",
+			want:       Match{Path: "keep.log", Allowed: false, Pattern: "keep.log", Line: 3},
+		},
+		{
+			name:       "negated rule",
+			ignoreFile: "last_match_wins",
+			path:       "important.log",
+			want:       Match{Path: "important.log", Allowed: true, Pattern: "!important.log", Line: 4, Negated: true},
+		},
+		{
+			name:       "no matching rule",
+			ignoreFile: "last_match_wins",
+			path:       "other.txt",
+			want:       Match{Path: "other.txt", Allowed: true},
+		},
+		{
+			name:       "excluded parent directory",
+			ignoreFile: "excluded_parent",
+			path:       "build/keep.txt",
+			want:       Match{Path: "build/keep.txt", Allowed: false, Pattern: "build/", Line: 1},
+		},
+		{
+			name:       "line numbers account for comments and blank lines",
+			ignoreFile: "go_jetbrains_windows",
+			path:       "go.work",
+			want:       Match{Path: "go.work", Allowed: false, Pattern: "go.work", Line: 60},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ignoreContents := test.Data(t, tt.ignoreFile)
+			location, cleanup := test.CopyToTempLocation(t, ignoreContents)
+			defer cleanup()
+
+			processor, err := NewProcessor(
+				WithGitignoreStrategy(),
+				WithIgnoreFilePath(location),
+			)
+			if err != nil {
+				t.Fatalf("NewProcessor() error = %v", err)
+			}
+
+			got, err := processor.Explain(tt.path)
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+
+			if tt.want.Pattern != "" {
+				tt.want.Source = location
+				if got.Rule == nil {
+					t.Fatalf("Explain() expected a deciding rule")
+				}
+				tt.want.Rule = got.Rule
+			}
+
+			if got != tt.want {
+				t.Errorf("Explain()\ngot=\n%+v\nwant=\n%+v", got, tt.want)
+			}
+		})
+	}
+}
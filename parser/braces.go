@@ -0,0 +1,240 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxBraceExpansions limits the patterns which may result from expanding a single line, e.g. {1..1000000}
+const maxBraceExpansions = 10000
+
+var (
+	numericRange   = regexp.MustCompile(`^([-+]?\d+)\.\.([-+]?\d+)(?:\.\.([-+]?\d+))?$`)
+	characterRange = regexp.MustCompile(`^([a-zA-Z])\.\.([a-zA-Z])(?:\.\.([-+]?\d+))?$`)
+)
+
+// ExpandBraces expands the brace expressions of pattern as a shell (or minimatch) would, providing every resulting
+// pattern in order. A brace expression is either a comma separated list of alternatives, which may themselves contain
+// brace expressions (a{b,c{d,e}} expands to ab, acd and ace), or a numeric or single character range with an optional
+// increment ({1..3}, {01..10..3} or {a..c}). Braces which form neither, such as {a} or an unbalanced {, are literal,
+// and a backslash escapes the following character.
+func ExpandBraces(pattern string) ([]string, error) {
+	expanded, err := expandBraces(pattern)
+	if err != nil {
+		return nil, newParsingError(err.Error())
+	}
+	return expanded, nil
+}
+
+func expandBraces(pattern string) ([]string, error) {
+	start, end, alternatives, err := firstBraceExpression(pattern)
+	if err != nil || start < 0 {
+		return []string{pattern}, err
+	}
+
+	prefix := pattern[:start]
+	suffixes, err := expandBraces(pattern[end+1:])
+	if err != nil {
+		return nil, err
+	}
+
+	expanded := make([]string, 0, len(alternatives)*len(suffixes))
+	for _, alternative := range alternatives {
+		alternativeExpanded, err := expandBraces(alternative)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range alternativeExpanded {
+			for _, suffix := range suffixes {
+				if len(expanded) == maxBraceExpansions {
+					return nil, fmt.Errorf("brace expansion exceeds %d patterns", maxBraceExpansions)
+				}
+				expanded = append(expanded, prefix+a+suffix)
+			}
+		}
+	}
+
+	return expanded, nil
+}
+
+// firstBraceExpression locates the leftmost brace expression of pattern, providing its bounds and alternatives. A
+// start of -1 indicates that pattern contains no brace expression.
+func firstBraceExpression(pattern string) (int, int, []string, error) {
+	for start := 0; start < len(pattern); start++ {
+		switch pattern[start] {
+		case '\\':
+			start++
+		case '{':
+			end := closingBrace(pattern, start)
+			if end < 0 {
+				// : an unbalanced brace is literal
+				continue
+			}
+
+			body := pattern[start+1 : end]
+			if alternatives := splitAlternatives(body); len(alternatives) > 1 {
+				return start, end, alternatives, nil
+			}
+
+			values, err := expandRange(body)
+			if err != nil {
+				return -1, -1, nil, err
+			}
+			if values != nil {
+				return start, end, values, nil
+			}
+			// : otherwise the braces are literal, although they may contain a brace expression (e.g. {a{b,c}})
+		}
+	}
+
+	return -1, -1, nil, nil
+}
+
+// closingBrace finds the index of the } balancing the { at start, or -1 if it's unbalanced
+func closingBrace(pattern string, start int) int {
+	depth := 0
+	for i := start; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitAlternatives splits body at each comma which isn't escaped or nested within braces
+func splitAlternatives(body string) []string {
+	alternatives := make([]string, 0)
+	depth := 0
+	last := 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				alternatives = append(alternatives, body[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(alternatives, body[last:])
+}
+
+// expandRange provides the values of a numeric or character range, or nil if body isn't a range
+func expandRange(body string) ([]string, error) {
+	if match := numericRange.FindStringSubmatch(body); match != nil {
+		from, fromErr := strconv.Atoi(match[1])
+		to, toErr := strconv.Atoi(match[2])
+		if fromErr != nil || toErr != nil {
+			return nil, fmt.Errorf("invalid range {%s}", body)
+		}
+
+		// : a range is zero padded when either bound is, e.g. {01..10}
+		width := 0
+		if padded(match[1]) || padded(match[2]) {
+			width = len(match[1])
+			if len(match[2]) > width {
+				width = len(match[2])
+			}
+		}
+
+		return rangeValues(from, to, match[3], func(value int) string {
+			return fmt.Sprintf("%0*d", width, value)
+		})
+	}
+
+	if match := characterRange.FindStringSubmatch(body); match != nil {
+		return rangeValues(int(match[1][0]), int(match[2][0]), match[3], func(value int) string {
+			return string(rune(value))
+		})
+	}
+
+	return nil, nil
+}
+
+// padded determines whether a range bound has leading zeros
+func padded(bound string) bool {
+	digits := strings.TrimLeft(bound, "-+")
+	return len(digits) > 1 && digits[0] == '0'
+}
+
+// rangeValues formats each value from from to to (inclusive, in either direction), in steps of increment
+func rangeValues(from int, to int, increment string, format func(value int) string) ([]string, error) {
+	step := uint64(1)
+	if increment != "" {
+		value, err := strconv.Atoi(increment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range increment %s", increment)
+		}
+		// : negating as unsigned also gives the magnitude of the smallest int
+		step = uint64(value)
+		if value < 0 {
+			step = -step
+		}
+		if step == 0 {
+			step = 1
+		}
+	}
+
+	// : the distance between any two ints fits in a uint64, whereas it may overflow an int
+	var span uint64
+	if from <= to {
+		span = uint64(to) - uint64(from)
+	} else {
+		span = uint64(from) - uint64(to)
+	}
+	if span/step >= maxBraceExpansions {
+		return nil, fmt.Errorf("brace expansion exceeds %d patterns", maxBraceExpansions)
+	}
+
+	count := span/step + 1
+	values := make([]string, 0, count)
+	for i := uint64(0); i < count; i++ {
+		offset := int(i * step)
+		if from <= to {
+			values = append(values, format(from+offset))
+		} else {
+			values = append(values, format(from-offset))
+		}
+	}
+	return values, nil
+}
+
+// parseExpanded parses each pattern resulting from brace expansion of text with parseLine, separating the tokens of
+// each pattern with an Alternative token. Each pattern's tokens refer to that pattern as their Line.
+func parseExpanded(text string, parseLine func(text string) ([]TokenValue, error)) ([]TokenValue, error) {
+	expanded, err := ExpandBraces(text)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]TokenValue, 0)
+	for i, pattern := range expanded {
+		if i > 0 {
+			parts = append(parts, TokenValue{Token: Alternative, Line: &text})
+		}
+
+		patternParts, err := parseLine(pattern)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, patternParts...)
+	}
+
+	return parts, nil
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandBraces(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+		wantErr bool
+	}{
+		{pattern: "plain", want: []string{"plain"}},
+		{pattern: "**/{first*,second*}", want: []string{"**/first*", "**/second*"}},
+		{pattern: "a{b,c{d,e}}f", want: []string{"abf", "acdf", "acef"}},
+		{pattern: "{a,b}{1,2}", want: []string{"a1", "a2", "b1", "b2"}},
+		{pattern: "a{,b}", want: []string{"a", "ab"}},
+		{pattern: "file{1..3}.txt", want: []string{"file1.txt", "file2.txt", "file3.txt"}},
+		{pattern: "{3..1}", want: []string{"3", "2", "1"}},
+		{pattern: "{-1..1}", want: []string{"-1", "0", "1"}},
+		{pattern: "{01..10..3}", want: []string{"01", "04", "07", "10"}},
+		{pattern: "{a..c}", want: []string{"a", "b", "c"}},
+		{pattern: "{a..e..2}", want: []string{"a", "c", "e"}},
+		{pattern: "{x,{1..2}}", want: []string{"x", "1", "2"}},
+		{pattern: "{a}", want: []string{"{a}"}},
+		{pattern: "{}", want: []string{"{}"}},
+		{pattern: "{a{b,c}}", want: []string{"{ab}", "{ac}"}},
+		{pattern: "{a,b", want: []string{"{a,b"}},
+		{pattern: "{x{a,b}", want: []string{"{xa", "{xb"}},
+		{pattern: `\{a,b}`, want: []string{`\{a,b}`}},
+		{pattern: `{a\,b,c}`, want: []string{`a\,b`, "c"}},
+		{pattern: "{1..3..0}", want: []string{"1", "2", "3"}},
+		{pattern: "{1..100000}", wantErr: true},
+		{pattern: "{0..9}{0..9}{0..9}{0..9}{0..9}", wantErr: true},
+		{pattern: "{0..9223372036854775807..9223372036854775807}", want: []string{"0", "9223372036854775807"}},
+		{pattern: "{9223372036854775807..0..9223372036854775807}", want: []string{"9223372036854775807", "0"}},
+		{pattern: "{-9223372036854775808..-9223372036854775807}", want: []string{"-9223372036854775808", "-9223372036854775807"}},
+		{pattern: "{1..2..-9223372036854775808}", want: []string{"1"}},
+		{pattern: "{-9223372036854775808..9223372036854775807}", wantErr: true},
+		{pattern: "{9223372036854775807..-9223372036854775808}", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, err := ExpandBraces(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandBraces() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) && !tt.wantErr {
+				t.Errorf("ExpandBraces() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
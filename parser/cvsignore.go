@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"io"
+	"strings"
+)
+
+type cvsignoreParser struct {
+}
+
+// ParseAll contents from reader to a collection of TokenValue
+func (c cvsignoreParser) ParseAll(reader io.Reader) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(c)
+	return helpers.ParseAll(reader)
+}
+
+// ParseAllText from text input to a collection of TokenValue
+func (c cvsignoreParser) ParseAllText(text string) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(c)
+	return helpers.ParseAllText(text)
+}
+
+// ParseLine parses a line of whitespace-separated patterns to a Text token for each. A lone ! clears every pattern
+// accumulated so far, so it results in a leading Clear token, and any patterns preceding it on the line are dropped.
+func (c cvsignoreParser) ParseLine(text string) ([]TokenValue, error) {
+	parts := make([]TokenValue, 0)
+	for _, field := range strings.Fields(text) {
+		if field == string(Negate) {
+			parts = append(parts[:0], TokenValue{Token: Clear, Line: &text})
+			continue
+		}
+
+		parts = append(parts, TokenValue{Token: Text, Value: field, Line: &text})
+	}
+	return parts, nil
+}
+
+// NewCvsignoreParser is a strategy which parses .cvsignore text line-by-line
+func NewCvsignoreParser() Parser {
+	return cvsignoreParser{}
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Parser = &cvsignoreParser{}
+)
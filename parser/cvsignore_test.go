@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jimschubert/ignore/internal/util"
+)
+
+func TestCvsignoreParser_ParseLine(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []TokenValue
+	}{
+		{name: "empty", text: " \t", want: []TokenValue{}},
+		{
+			name: "whitespace separated",
+			text: "*.o\tcore  #*",
+			want: []TokenValue{
+				{Token: Text, Value: "*.o", Line: util.Ptr("*.o\tcore  #*")},
+				{Token: Text, Value: "core", Line: util.Ptr("*.o\tcore  #*")},
+				{Token: Text, Value: "#*", Line: util.Ptr("*.o\tcore  #*")},
+			},
+		},
+		{
+			name: "clear",
+			text: "!",
+			want: []TokenValue{{Token: Clear, Line: util.Ptr("!")}},
+		},
+		{
+			name: "clear drops preceding patterns",
+			text: "a ! b",
+			want: []TokenValue{
+				{Token: Clear, Line: util.Ptr("a ! b")},
+				{Token: Text, Value: "b", Line: util.Ptr("a ! b")},
+			},
+		},
+		{
+			name: "negation is not special within a pattern",
+			text: "!a",
+			want: []TokenValue{{Token: Text, Value: "!a", Line: util.Ptr("!a")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cvsignoreParser{}.ParseLine(tt.text)
+			if err != nil {
+				t.Errorf("ParseLine() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
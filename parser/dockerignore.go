@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+type dockerignoreParser struct {
+	options options
+}
+
+// ParseAll contents from reader to a collection of TokenValue
+func (d dockerignoreParser) ParseAll(reader io.Reader) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(d)
+	return helpers.ParseAll(reader)
+}
+
+// ParseAllText from text input to a collection of TokenValue
+func (d dockerignoreParser) ParseAllText(text string) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(d)
+	return helpers.ParseAllText(text)
+}
+
+// ParseLine parses a line of text. Unlike gitignore, the pattern isn't tokenized beyond comments and negation:
+// Docker cleans each pattern as a path, and the pattern's Text value is that cleaned, slash-separated form.
+func (d dockerignoreParser) ParseLine(text string) ([]TokenValue, error) {
+	if d.options.braceExpansion && !strings.HasPrefix(text, string(Comment)) {
+		return parseExpanded(text, d.parseLine)
+	}
+	return d.parseLine(text)
+}
+
+// parseLine parses a line of text, without brace expansion
+func (d dockerignoreParser) parseLine(text string) ([]TokenValue, error) {
+	parts := make([]TokenValue, 0)
+
+	// : Lines starting with # are treated as comments (only when # is the very first character)
+	if strings.HasPrefix(text, string(Comment)) {
+		commentText := strings.TrimSpace(strings.TrimPrefix(text, string(Comment)))
+		parts = append(parts, TokenValue{Token: Comment, Value: commentText, Line: &text})
+		return parts, nil
+	}
+
+	pattern := strings.TrimSpace(text)
+	if pattern == "" {
+		return parts, nil
+	}
+
+	if strings.HasPrefix(pattern, string(Negate)) {
+		pattern = strings.TrimSpace(strings.TrimPrefix(pattern, string(Negate)))
+		if pattern == "" {
+			return parts, newParsingError("negation with no negated pattern")
+		}
+		parts = append(parts, TokenValue{Token: Negate, Line: &text})
+	}
+
+	pattern = filepath.ToSlash(filepath.Clean(pattern))
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") {
+		// patterns are always relative to the root of the build context
+		pattern = strings.TrimPrefix(pattern, "/")
+	}
+
+	parts = append(parts, TokenValue{Token: Text, Value: pattern, Line: &text})
+	return parts, nil
+}
+
+// NewDockerignoreParser is a strategy which parses .dockerignore text line-by-line, with any optional features of opts
+func NewDockerignoreParser(opts ...Option) Parser {
+	return dockerignoreParser{options: newOptions(opts)}
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Parser = &dockerignoreParser{}
+)
@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jimschubert/ignore/internal/util"
+)
+
+func TestDockerignoreParser_ParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []TokenValue
+		wantErr bool
+	}{
+		{
+			name: "comment",
+			text: "# build output",
+			want: []TokenValue{{Token: Comment, Value: "build output", Line: util.Ptr("# build output")}},
+		},
+		{
+			name: "empty",
+			text: "   ",
+			want: []TokenValue{},
+		},
+		{
+			name: "cleaned pattern",
+			text: "  ./build//output/../bin  ",
+			want: []TokenValue{{Token: Text, Value: "build/bin", Line: util.Ptr("  ./build//output/../bin  ")}},
+		},
+		{
+			name: "rooted pattern",
+			text: "/node_modules",
+			want: []TokenValue{{Token: Text, Value: "node_modules", Line: util.Ptr("/node_modules")}},
+		},
+		{
+			name: "exception",
+			text: "! README.md",
+			want: []TokenValue{
+				{Token: Negate, Line: util.Ptr("! README.md")},
+				{Token: Text, Value: "README.md", Line: util.Ptr("! README.md")},
+			},
+		},
+		{
+			name:    "exception without pattern",
+			text:    "!",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dockerignoreParser{}.ParseLine(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"io"
+	"strings"
+)
+
+type gcloudignoreParser struct {
+	gitignore Parser
+}
+
+// ParseAll contents from reader to a collection of TokenValue
+func (g gcloudignoreParser) ParseAll(reader io.Reader) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(g)
+	return helpers.ParseAll(reader)
+}
+
+// ParseAllText from text input to a collection of TokenValue
+func (g gcloudignoreParser) ParseAllText(text string) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(g)
+	return helpers.ParseAllText(text)
+}
+
+// ParseLine parses a line of text as gitignore syntax, except for an include directive (#!include:path) which results
+// in a single Include token whose Value is the path of the included file.
+func (g gcloudignoreParser) ParseLine(text string) ([]TokenValue, error) {
+	if strings.HasPrefix(text, string(Include)) {
+		included := strings.TrimSpace(strings.TrimPrefix(text, string(Include)))
+		if included == "" {
+			return nil, newParsingError("include directive with no file")
+		}
+		return []TokenValue{{Token: Include, Value: included, Line: &text}}, nil
+	}
+
+	return g.gitignore.ParseLine(text)
+}
+
+// NewGcloudignoreParser is a strategy which parses .gcloudignore text line-by-line: gitignore syntax, along with
+// #!include directives which splice the rules of another file in place. Any optional features of opts apply to the
+// gitignore syntax, rather than include directives.
+func NewGcloudignoreParser(opts ...Option) Parser {
+	return gcloudignoreParser{gitignore: NewGitignoreParser(opts...)}
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Parser = &gcloudignoreParser{}
+)
@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jimschubert/ignore/internal/util"
+)
+
+func TestGcloudignoreParser_ParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []TokenValue
+		wantErr bool
+	}{
+		{
+			name: "include directive",
+			text: "#!include:.gitignore",
+			want: []TokenValue{{Token: Include, Value: ".gitignore", Line: util.Ptr("#!include:.gitignore")}},
+		},
+		{
+			name: "comment",
+			text: "# include:.gitignore",
+			want: []TokenValue{{Token: Comment, Value: "include:.gitignore", Line: util.Ptr("# include:.gitignore")}},
+		},
+		{
+			name: "gitignore pattern",
+			text: "/abcd",
+			want: []TokenValue{
+				{Token: RootedMarker, Line: util.Ptr("/abcd")},
+				{Token: Text, Value: "abcd", Line: util.Ptr("/abcd")},
+			},
+		},
+		{
+			name:    "include without file",
+			text:    "#!include:",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGcloudignoreParser().ParseLine(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
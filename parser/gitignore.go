@@ -7,6 +7,7 @@ import (
 )
 
 type gitignoreParser struct {
+	options options
 }
 
 // ParseAll contents from reader to a collection of TokenValue
@@ -23,6 +24,16 @@ func (l gitignoreParser) ParseAllText(text string) ([]TokenValue, error) {
 
 // ParseLine parses a line of text
 func (l gitignoreParser) ParseLine(text string) ([]TokenValue, error) {
+	// : Trailing spaces are ignored unless they are quoted with backslash ("`\`")
+	text = trimTrailingSpaces(text)
+	if l.options.braceExpansion && !strings.HasPrefix(text, string(Comment)) {
+		return parseExpanded(text, l.parseLine)
+	}
+	return l.parseLine(text)
+}
+
+// parseLine parses a line of text, without brace expansion
+func (l gitignoreParser) parseLine(text string) ([]TokenValue, error) {
 	parts := make([]TokenValue, 0)
 
 	switch {
@@ -97,7 +108,50 @@ func (l gitignoreParser) ParseLine(text string) ([]TokenValue, error) {
 			continue
 		}
 
+		if Question.MatchRune(current) {
+			if buf.Len() > 0 {
+				parts = append(parts, TokenValue{Token: Text, Value: buf.String(), Line: &text})
+				buf.Reset()
+			}
+
+			parts = append(parts, TokenValue{Token: Question, Line: &text})
+			continue
+		}
+
+		if CharClass.MatchRune(current) {
+			// : an unterminated bracket expression is matched literally
+			if end := bracketEnd(runes, i); end > i {
+				if buf.Len() > 0 {
+					parts = append(parts, TokenValue{Token: Text, Value: buf.String(), Line: &text})
+					buf.Reset()
+				}
+
+				parts = append(parts, TokenValue{Token: CharClass, Value: string(runes[i : end+1]), Line: &text})
+				i = end
+				continue
+			}
+		}
+
+		if Escape.MatchRune(current) && i < last && next != ' ' {
+			// an escaped wildcard or bracket (e.g. \* or \[) is literal text. As with a leading escape, the escape
+			// character is tracked as its own token, and the escaped character begins the following text.
+			if buf.Len() > 0 {
+				parts = append(parts, TokenValue{Token: Text, Value: buf.String(), Line: &text})
+				buf.Reset()
+			}
+
+			parts = append(parts, TokenValue{Token: Escape, Line: &text})
+			buf.WriteRune(next)
+			i++
+			continue
+		}
+
 		if EscapedSpace.MatchRunes(current, next) {
+			if buf.Len() > 0 {
+				parts = append(parts, TokenValue{Token: Text, Value: buf.String(), Line: &text})
+				buf.Reset()
+			}
+
 			parts = append(parts, TokenValue{Token: EscapedSpace, Line: &text})
 			i++
 			continue
@@ -137,9 +191,55 @@ func (l gitignoreParser) ParseLine(text string) ([]TokenValue, error) {
 	return parts, nil
 }
 
-// NewGitignoreParser is a strategy which parses input text line-by-line
-func NewGitignoreParser() Parser {
-	return gitignoreParser{}
+// trimTrailingSpaces removes the spaces ending text, stopping at a space escaped by a backslash (e.g. "a\ ")
+func trimTrailingSpaces(text string) string {
+	end := len(text)
+	for end > 0 && text[end-1] == ' ' {
+		// an odd number of backslashes before the space escapes it
+		backslashes := 0
+		for i := end - 2; i >= 0 && text[i] == '\\'; i-- {
+			backslashes++
+		}
+		if backslashes%2 == 1 {
+			break
+		}
+		end--
+	}
+	return text[:end]
+}
+
+// bracketEnd finds the index of the ] closing the bracket expression (e.g. [a-z], [!abc] or [[:alpha:]]) opened at
+// start, or -1 if the expression is unterminated. As with fnmatch, a ] immediately following [ or [! is a member of the
+// set, and a backslash escapes the following character.
+func bracketEnd(runes []rune, start int) int {
+	i := start + 1
+	if i < len(runes) && (runes[i] == '!' || runes[i] == '^') {
+		i++
+	}
+	if i < len(runes) && runes[i] == ']' {
+		i++
+	}
+
+	for ; i < len(runes); i++ {
+		switch {
+		case runes[i] == ']':
+			return i
+		case runes[i] == '\\':
+			i++
+		case runes[i] == '[' && i+1 < len(runes) && runes[i+1] == ':':
+			// : a character class such as [:alpha:] may contain ]
+			if end := strings.Index(string(runes[i+2:]), ":]"); end >= 0 {
+				i += 2 + len([]rune(string(runes[i+2:])[:end])) + 1
+			}
+		}
+	}
+
+	return -1
+}
+
+// NewGitignoreParser is a strategy which parses input text line-by-line, with any optional features of opts
+func NewGitignoreParser(opts ...Option) Parser {
+	return gitignoreParser{options: newOptions(opts)}
 }
 
 // Forces compilation error if interface contract changes (for any reflection use cases)
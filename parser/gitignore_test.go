@@ -73,6 +73,85 @@ func TestLineParser_parse(t *testing.T) {
 				{Token: Text, Value: "sample.txt", Line: util.Ptr("**/abcd/**/foo/bar/sample.txt")},
 			},
 		},
+		{
+			name: "single character wildcard",
+			args: args{"**/?.?"},
+			want: []TokenValue{
+				{Token: MatchAll, Line: util.Ptr("**/?.?")},
+				{Token: PathDelim, Line: util.Ptr("**/?.?")},
+				{Token: Question, Line: util.Ptr("**/?.?")},
+				{Token: Text, Value: ".", Line: util.Ptr("**/?.?")},
+				{Token: Question, Line: util.Ptr("**/?.?")},
+			},
+		},
+		{
+			name: "character class",
+			args: args{"file[0-9].txt"},
+			want: []TokenValue{
+				{Token: Text, Value: "file", Line: util.Ptr("file[0-9].txt")},
+				{Token: CharClass, Value: "[0-9]", Line: util.Ptr("file[0-9].txt")},
+				{Token: Text, Value: ".txt", Line: util.Ptr("file[0-9].txt")},
+			},
+		},
+		{
+			name: "negated character class",
+			args: args{"[!abc]*"},
+			want: []TokenValue{
+				{Token: CharClass, Value: "[!abc]", Line: util.Ptr("[!abc]*")},
+				{Token: MatchAny, Line: util.Ptr("[!abc]*")},
+			},
+		},
+		{
+			name: "named character class",
+			args: args{"[[:alpha:]]"},
+			want: []TokenValue{
+				{Token: CharClass, Value: "[[:alpha:]]", Line: util.Ptr("[[:alpha:]]")},
+			},
+		},
+		{
+			name: "bracket as first class member",
+			args: args{"[]a]"},
+			want: []TokenValue{
+				{Token: CharClass, Value: "[]a]", Line: util.Ptr("[]a]")},
+			},
+		},
+		{
+			name: "unterminated character class",
+			args: args{"[abc"},
+			want: []TokenValue{
+				{Token: Text, Value: "[abc", Line: util.Ptr("[abc")},
+			},
+		},
+		{
+			name: "escaped brackets and wildcards",
+			args: args{`a\[b\]\*\?`},
+			want: []TokenValue{
+				{Token: Text, Value: "a", Line: util.Ptr(`a\[b\]\*\?`)},
+				{Token: Escape, Line: util.Ptr(`a\[b\]\*\?`)},
+				{Token: Text, Value: "[b", Line: util.Ptr(`a\[b\]\*\?`)},
+				{Token: Escape, Line: util.Ptr(`a\[b\]\*\?`)},
+				{Token: Text, Value: "]", Line: util.Ptr(`a\[b\]\*\?`)},
+				{Token: Escape, Line: util.Ptr(`a\[b\]\*\?`)},
+				{Token: Text, Value: "*", Line: util.Ptr(`a\[b\]\*\?`)},
+				{Token: Escape, Line: util.Ptr(`a\[b\]\*\?`)},
+				{Token: Text, Value: "?", Line: util.Ptr(`a\[b\]\*\?`)},
+			},
+		},
+		{
+			name: "trailing spaces",
+			args: args{"foo.txt   "},
+			want: []TokenValue{
+				{Token: Text, Value: "foo.txt", Line: util.Ptr("foo.txt")},
+			},
+		},
+		{
+			name: "escaped trailing space",
+			args: args{"foo\\  "},
+			want: []TokenValue{
+				{Token: Text, Value: "foo", Line: util.Ptr("foo\\ ")},
+				{Token: EscapedSpace, Line: util.Ptr("foo\\ ")},
+			},
+		},
 		{
 			name:    "triple star",
 			args:    args{"***"},
@@ -150,3 +229,76 @@ func TestLineParser_parseSingleTokens(t *testing.T) {
 		})
 	}
 }
+
+func TestGitignoreParser_braceExpansion(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Parser
+		text string
+		want []TokenValue
+	}{
+		{
+			name: "expands alternatives",
+			p:    NewGitignoreParser(WithBraceExpansion()),
+			text: "*.{js,ts}",
+			want: []TokenValue{
+				{Token: MatchAny, Line: util.Ptr("*.js")},
+				{Token: Text, Value: ".js", Line: util.Ptr("*.js")},
+				{Token: Alternative, Line: util.Ptr("*.{js,ts}")},
+				{Token: MatchAny, Line: util.Ptr("*.ts")},
+				{Token: Text, Value: ".ts", Line: util.Ptr("*.ts")},
+			},
+		},
+		{
+			name: "comments are not expanded",
+			p:    NewGitignoreParser(WithBraceExpansion()),
+			text: "# {a,b}",
+			want: []TokenValue{
+				{Token: Comment, Value: "{a,b}", Line: util.Ptr("# {a,b}")},
+			},
+		},
+		{
+			name: "disabled by default",
+			p:    NewGitignoreParser(),
+			text: "{a,b}",
+			want: []TokenValue{
+				{Token: Text, Value: "{a,b}", Line: util.Ptr("{a,b}")},
+			},
+		},
+		{
+			name: "gcloudignore",
+			p:    NewGcloudignoreParser(WithBraceExpansion()),
+			text: "/{a,b}",
+			want: []TokenValue{
+				{Token: RootedMarker, Line: util.Ptr("/a")},
+				{Token: Text, Value: "a", Line: util.Ptr("/a")},
+				{Token: Alternative, Line: util.Ptr("/{a,b}")},
+				{Token: RootedMarker, Line: util.Ptr("/b")},
+				{Token: Text, Value: "b", Line: util.Ptr("/b")},
+			},
+		},
+		{
+			name: "dockerignore",
+			p:    NewDockerignoreParser(WithBraceExpansion()),
+			text: "!{a,b}/c",
+			want: []TokenValue{
+				{Token: Negate, Line: util.Ptr("!a/c")},
+				{Token: Text, Value: "a/c", Line: util.Ptr("!a/c")},
+				{Token: Alternative, Line: util.Ptr("!{a,b}/c")},
+				{Token: Negate, Line: util.Ptr("!b/c")},
+				{Token: Text, Value: "b/c", Line: util.Ptr("!b/c")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.ParseLine(tt.text)
+			if err != nil {
+				t.Fatalf("ParseLine() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
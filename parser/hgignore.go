@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Mercurial pattern syntaxes, carried as the Value of a Syntax token
+const (
+	// HgRegexp patterns are regular expressions matching anywhere within a path, unless anchored with ^
+	HgRegexp = "regexp"
+	// HgGlob patterns are shell-style globs matching at any directory level
+	HgGlob = "glob"
+	// HgRootGlob patterns are shell-style globs anchored at the repository root
+	HgRootGlob = "rootglob"
+	// HgPath patterns are literal paths anchored at the repository root
+	HgPath = "path"
+)
+
+// hgSyntaxNames maps the names accepted by .hgignore (for both "syntax:" lines and per-line prefixes) to a syntax
+var hgSyntaxNames = map[string]string{
+	"re":       HgRegexp,
+	"regexp":   HgRegexp,
+	"relre":    HgRegexp,
+	"glob":     HgGlob,
+	"relglob":  HgGlob,
+	"rootglob": HgRootGlob,
+	"path":     HgPath,
+	"relpath":  HgPath,
+}
+
+// hgComment matches an unescaped # and anything following it
+var hgComment = regexp.MustCompile(`((?:^|[^\\])(?:\\\\)*)#.*`)
+
+type hgignoreParser struct {
+}
+
+// ParseAll contents from reader to a collection of TokenValue. Each pattern is parsed in the syntax declared by the
+// most recent "syntax:" line, which defaults to regexp.
+func (h hgignoreParser) ParseAll(reader io.Reader) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(&hgignoreSession{syntax: HgRegexp})
+	return helpers.ParseAll(reader)
+}
+
+// ParseAllText from text input to a collection of TokenValue
+func (h hgignoreParser) ParseAllText(text string) ([]TokenValue, error) {
+	return h.ParseAll(strings.NewReader(text))
+}
+
+// ParseLine parses a single line of text in the default (regexp) syntax. A "syntax:" line is parsed, but has no
+// effect beyond the returned tokens; use ParseAll to carry the syntax across lines.
+func (h hgignoreParser) ParseLine(text string) ([]TokenValue, error) {
+	return (&hgignoreSession{syntax: HgRegexp}).ParseLine(text)
+}
+
+// hgignoreSession parses the lines of a single .hgignore file, carrying the active syntax from one line to the next
+type hgignoreSession struct {
+	syntax string
+}
+
+// ParseAll …
+func (s *hgignoreSession) ParseAll(reader io.Reader) ([]TokenValue, error) {
+	return NewParsingHelpers(s).ParseAll(reader)
+}
+
+// ParseAllText …
+func (s *hgignoreSession) ParseAllText(text string) ([]TokenValue, error) {
+	return NewParsingHelpers(s).ParseAllText(text)
+}
+
+// ParseLine parses a line of text. A "syntax:" line results in a lone Syntax token, and changes the syntax of
+// subsequent lines. A pattern results in a Syntax token, whose Value is the pattern's syntax, followed by a Text token.
+func (s *hgignoreSession) ParseLine(text string) ([]TokenValue, error) {
+	parts := make([]TokenValue, 0)
+
+	// : Comments begin with # (anywhere in a line), and \# is a literal #
+	if strings.HasPrefix(text, string(Comment)) {
+		commentText := strings.TrimSpace(strings.TrimPrefix(text, string(Comment)))
+		parts = append(parts, TokenValue{Token: Comment, Value: commentText, Line: &text})
+		return parts, nil
+	}
+
+	line := hgComment.ReplaceAllString(text, "$1")
+	line = strings.TrimRight(strings.ReplaceAll(line, `\#`, "#"), " \t\r")
+	if line == "" {
+		return parts, nil
+	}
+
+	if strings.HasPrefix(line, string(Syntax)) {
+		name := strings.TrimSpace(strings.TrimPrefix(line, string(Syntax)))
+		syntax, ok := hgSyntaxNames[name]
+		if !ok {
+			return parts, newParsingError("invalid syntax '" + name + "'")
+		}
+
+		s.syntax = syntax
+		parts = append(parts, TokenValue{Token: Syntax, Value: syntax, Line: &text})
+		return parts, nil
+	}
+
+	syntax := s.syntax
+	if prefix, pattern, found := strings.Cut(line, ":"); found {
+		if named, ok := hgSyntaxNames[prefix]; ok {
+			syntax, line = named, pattern
+		} else if prefix == "include" || prefix == "subinclude" {
+			return parts, newParsingError(prefix + " directives are not supported")
+		}
+	}
+
+	parts = append(parts,
+		TokenValue{Token: Syntax, Value: syntax, Line: &text},
+		TokenValue{Token: Text, Value: line, Line: &text},
+	)
+	return parts, nil
+}
+
+// NewHgignoreParser is a strategy which parses Mercurial's .hgignore text, in which "syntax:" lines switch the
+// syntax of the patterns which follow.
+func NewHgignoreParser() Parser {
+	return hgignoreParser{}
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Parser = &hgignoreParser{}
+	_ Parser = &hgignoreSession{}
+)
@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jimschubert/ignore/internal/util"
+)
+
+func TestHgignoreParser_ParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []TokenValue
+		wantErr bool
+	}{
+		{
+			name: "comment",
+			text: "# build output",
+			want: []TokenValue{{Token: Comment, Value: "build output", Line: util.Ptr("# build output")}},
+		},
+		{
+			name: "empty",
+			text: "  ",
+			want: []TokenValue{},
+		},
+		{
+			name: "defaults to regexp",
+			text: `\.orig$`,
+			want: []TokenValue{
+				{Token: Syntax, Value: HgRegexp, Line: util.Ptr(`\.orig$`)},
+				{Token: Text, Value: `\.orig$`, Line: util.Ptr(`\.orig$`)},
+			},
+		},
+		{
+			name: "inline comment and escaped hash",
+			text: `a\#b  # trailing`,
+			want: []TokenValue{
+				{Token: Syntax, Value: HgRegexp, Line: util.Ptr(`a\#b  # trailing`)},
+				{Token: Text, Value: "a#b", Line: util.Ptr(`a\#b  # trailing`)},
+			},
+		},
+		{
+			name: "syntax line",
+			text: "syntax: glob",
+			want: []TokenValue{{Token: Syntax, Value: HgGlob, Line: util.Ptr("syntax: glob")}},
+		},
+		{
+			name: "syntax prefix",
+			text: "rootglob:*.cfg",
+			want: []TokenValue{
+				{Token: Syntax, Value: HgRootGlob, Line: util.Ptr("rootglob:*.cfg")},
+				{Token: Text, Value: "*.cfg", Line: util.Ptr("rootglob:*.cfg")},
+			},
+		},
+		{
+			name: "unknown prefix is part of the pattern",
+			text: "c:/temp",
+			want: []TokenValue{
+				{Token: Syntax, Value: HgRegexp, Line: util.Ptr("c:/temp")},
+				{Token: Text, Value: "c:/temp", Line: util.Ptr("c:/temp")},
+			},
+		},
+		{name: "invalid syntax", text: "syntax: fancy", wantErr: true},
+		{name: "include", text: "include:other.hgignore", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hgignoreParser{}.ParseLine(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHgignoreParser_ParseAllText(t *testing.T) {
+	text := "a\nsyntax: glob\nb\nsyntax: regexp\nc"
+	got, err := NewHgignoreParser().ParseAllText(text)
+	if err != nil {
+		t.Fatalf("ParseAllText() error = %v", err)
+	}
+
+	syntaxes := make([]string, 0)
+	for i, part := range got {
+		if part.Token == Text {
+			syntaxes = append(syntaxes, got[i-1].Value+":"+part.Value)
+		}
+	}
+
+	want := []string{"regexp:a", "glob:b", "regexp:c"}
+	if !reflect.DeepEqual(syntaxes, want) {
+		t.Errorf("ParseAllText() syntaxes = %v, want %v", syntaxes, want)
+	}
+
+	// each call begins in the default syntax
+	again, _ := NewHgignoreParser().ParseAllText("a")
+	if again[0].Value != HgRegexp {
+		t.Errorf("ParseAllText() syntax = %v, want %v", again[0].Value, HgRegexp)
+	}
+}
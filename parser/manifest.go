@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"io"
+	"strings"
+)
+
+// MANIFEST.in commands, carried as the Value of a Command token
+const (
+	ManifestInclude          = "include"
+	ManifestExclude          = "exclude"
+	ManifestRecursiveInclude = "recursive-include"
+	ManifestRecursiveExclude = "recursive-exclude"
+	ManifestGlobalInclude    = "global-include"
+	ManifestGlobalExclude    = "global-exclude"
+	ManifestGraft            = "graft"
+	ManifestPrune            = "prune"
+)
+
+// manifestArguments is the form of each command's arguments: "p" for one or more patterns, "dp" for a directory
+// followed by one or more patterns, and "d" for a single directory
+var manifestArguments = map[string]string{
+	ManifestInclude:          "p",
+	ManifestExclude:          "p",
+	ManifestRecursiveInclude: "dp",
+	ManifestRecursiveExclude: "dp",
+	ManifestGlobalInclude:    "p",
+	ManifestGlobalExclude:    "p",
+	ManifestGraft:            "d",
+	ManifestPrune:            "d",
+}
+
+type manifestParser struct {
+}
+
+// ParseAll contents from reader to a collection of TokenValue
+func (m manifestParser) ParseAll(reader io.Reader) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(m)
+	return helpers.ParseAll(reader)
+}
+
+// ParseAllText from text input to a collection of TokenValue
+func (m manifestParser) ParseAllText(text string) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(m)
+	return helpers.ParseAllText(text)
+}
+
+// ParseLine parses a line of text to a Command token, whose Value is the command, followed by a Text token for each
+// of its whitespace-separated arguments.
+func (m manifestParser) ParseLine(text string) ([]TokenValue, error) {
+	parts := make([]TokenValue, 0)
+
+	line := strings.TrimSpace(text)
+	if line == "" {
+		return parts, nil
+	}
+
+	// : Lines beginning with # are comments
+	if strings.HasPrefix(line, string(Comment)) {
+		commentText := strings.TrimSpace(strings.TrimPrefix(line, string(Comment)))
+		parts = append(parts, TokenValue{Token: Comment, Value: commentText, Line: &text})
+		return parts, nil
+	}
+
+	fields := strings.Fields(line)
+	command, arguments := fields[0], fields[1:]
+	form, ok := manifestArguments[command]
+	if !ok {
+		return parts, newParsingError("unknown command '" + command + "'")
+	}
+
+	switch {
+	case form == "d" && len(arguments) != 1:
+		return parts, newParsingError("'" + command + "' expects a single directory")
+	case form == "dp" && len(arguments) < 2:
+		return parts, newParsingError("'" + command + "' expects a directory and at least one pattern")
+	case len(arguments) < 1:
+		return parts, newParsingError("'" + command + "' expects at least one pattern")
+	}
+
+	parts = append(parts, TokenValue{Token: Command, Value: command, Line: &text})
+	for _, argument := range arguments {
+		parts = append(parts, TokenValue{Token: Text, Value: argument, Line: &text})
+	}
+	return parts, nil
+}
+
+// NewManifestParser is a strategy which parses Python's MANIFEST.in text line-by-line
+func NewManifestParser() Parser {
+	return manifestParser{}
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Parser = &manifestParser{}
+)
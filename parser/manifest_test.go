@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jimschubert/ignore/internal/util"
+)
+
+func TestManifestParser_ParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []TokenValue
+		wantErr bool
+	}{
+		{
+			name: "comment",
+			text: "# sdist contents",
+			want: []TokenValue{{Token: Comment, Value: "sdist contents", Line: util.Ptr("# sdist contents")}},
+		},
+		{
+			name: "empty",
+			text: " ",
+			want: []TokenValue{},
+		},
+		{
+			name: "multiple patterns",
+			text: "include  *.txt\t*.rst",
+			want: []TokenValue{
+				{Token: Command, Value: ManifestInclude, Line: util.Ptr("include  *.txt\t*.rst")},
+				{Token: Text, Value: "*.txt", Line: util.Ptr("include  *.txt\t*.rst")},
+				{Token: Text, Value: "*.rst", Line: util.Ptr("include  *.txt\t*.rst")},
+			},
+		},
+		{
+			name: "directory",
+			text: "graft src",
+			want: []TokenValue{
+				{Token: Command, Value: ManifestGraft, Line: util.Ptr("graft src")},
+				{Token: Text, Value: "src", Line: util.Ptr("graft src")},
+			},
+		},
+		{name: "unknown command", text: "add *.txt", wantErr: true},
+		{name: "missing pattern", text: "global-exclude", wantErr: true},
+		{name: "missing recursive pattern", text: "recursive-include docs", wantErr: true},
+		{name: "too many directories", text: "prune a b", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := manifestParser{}.ParseLine(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package parser
+
+// Option enables an optional feature of a Parser, such as brace expansion
+type Option func(*options)
+
+// options are the optional features enabled for a Parser
+type options struct {
+	braceExpansion bool
+}
+
+// WithBraceExpansion enables expansion of brace expressions (see ExpandBraces), as supported by minimatch-based tools
+// such as .eslintignore or .vscodeignore. Each line results in a rule per expanded pattern, separated by an Alternative
+// token, which share that line's number. Git doesn't support brace expansion, so it isn't enabled by default.
+func WithBraceExpansion() Option {
+	return func(o *options) {
+		o.braceExpansion = true
+	}
+}
+
+// newOptions applies opts to the default options
+func newOptions(opts []Option) options {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"io"
+	"strings"
+)
+
+// rsync filter rule actions, carried as the Value of a Filter token
+const (
+	RsyncInclude = "+"
+	RsyncExclude = "-"
+	RsyncProtect = "P"
+	RsyncRisk    = "R"
+	RsyncHide    = "H"
+	RsyncShow    = "S"
+)
+
+// rsyncRuleNames maps the long and short names of rsync filter rules to a short name
+var rsyncRuleNames = map[string]string{
+	"include":   RsyncInclude,
+	"exclude":   RsyncExclude,
+	"protect":   RsyncProtect,
+	"risk":      RsyncRisk,
+	"hide":      RsyncHide,
+	"show":      RsyncShow,
+	"merge":     ".",
+	"dir-merge": ":",
+	"clear":     "!",
+}
+
+// rsyncModifiers are the modifiers accepted by filter (not merge) rules
+const rsyncModifiers = "!srp"
+
+type rsyncFilterParser struct {
+}
+
+// ParseAll contents from reader to a collection of TokenValue
+func (r rsyncFilterParser) ParseAll(reader io.Reader) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(r)
+	return helpers.ParseAll(reader)
+}
+
+// ParseAllText from text input to a collection of TokenValue
+func (r rsyncFilterParser) ParseAllText(text string) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(r)
+	return helpers.ParseAllText(text)
+}
+
+// ParseLine parses a line of text of the form RULE[,MODIFIERS] [PATTERN_OR_FILENAME], where RULE is a long name
+// (e.g. exclude) or a short name (e.g. -). Filter rules result in a Filter token whose Value is the short name, any
+// Modifier token, and a Text token holding the pattern. A merge results in an Include token, a dir-merge in a
+// DirMerge token, and a clear in a lone Clear token.
+func (r rsyncFilterParser) ParseLine(text string) ([]TokenValue, error) {
+	parts := make([]TokenValue, 0)
+
+	line := strings.TrimSuffix(text, "\r")
+	if strings.TrimSpace(line) == "" {
+		return parts, nil
+	}
+
+	// : Lines beginning with # or ; are comments
+	if strings.HasPrefix(line, string(Comment)) || strings.HasPrefix(line, ";") {
+		commentText := strings.TrimSpace(line[1:])
+		parts = append(parts, TokenValue{Token: Comment, Value: commentText, Line: &text})
+		return parts, nil
+	}
+
+	name, argument := line, ""
+	if i := strings.IndexAny(line, " _"); i >= 0 {
+		name, argument = line[:i], line[i+1:]
+	}
+
+	action, modifiers := "", ""
+	if short := name[:1]; strings.Contains("+-PRHS.:!", short) {
+		action, modifiers = short, strings.TrimPrefix(name[1:], ",")
+	} else {
+		long, mods, _ := strings.Cut(name, ",")
+		if action = rsyncRuleNames[long]; action == "" {
+			return parts, newParsingError("unknown filter rule '" + long + "'")
+		}
+		modifiers = mods
+	}
+
+	switch action {
+	case "!":
+		if modifiers != "" || argument != "" {
+			return parts, newParsingError("clear takes no modifiers or arguments")
+		}
+		parts = append(parts, TokenValue{Token: Clear, Line: &text})
+		return parts, nil
+	case ".", ":":
+		if modifiers != "" {
+			return parts, newParsingError("merge modifiers '" + modifiers + "' are not supported")
+		}
+		if argument == "" {
+			return parts, newParsingError("merge rule with no file")
+		}
+
+		token := Include
+		if action == ":" {
+			token = DirMerge
+		}
+		parts = append(parts, TokenValue{Token: token, Value: argument, Line: &text})
+		return parts, nil
+	}
+
+	for _, modifier := range modifiers {
+		if !strings.ContainsRune(rsyncModifiers, modifier) {
+			return parts, newParsingError("unsupported modifier '" + string(modifier) + "'")
+		}
+	}
+	if argument == "" {
+		return parts, newParsingError("filter rule with no pattern")
+	}
+
+	parts = append(parts, TokenValue{Token: Filter, Value: action, Line: &text})
+	if modifiers != "" {
+		parts = append(parts, TokenValue{Token: Modifier, Value: modifiers, Line: &text})
+	}
+	parts = append(parts, TokenValue{Token: Text, Value: argument, Line: &text})
+	return parts, nil
+}
+
+// NewRsyncFilterParser is a strategy which parses rsync filter rules (e.g. an .rsync-filter file) line-by-line
+func NewRsyncFilterParser() Parser {
+	return rsyncFilterParser{}
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Parser = &rsyncFilterParser{}
+)
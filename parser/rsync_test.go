@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jimschubert/ignore/internal/util"
+)
+
+func TestRsyncFilterParser_ParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []TokenValue
+		wantErr bool
+	}{
+		{
+			name: "hash comment",
+			text: "# outputs",
+			want: []TokenValue{{Token: Comment, Value: "outputs", Line: util.Ptr("# outputs")}},
+		},
+		{
+			name: "semicolon comment",
+			text: "; outputs",
+			want: []TokenValue{{Token: Comment, Value: "outputs", Line: util.Ptr("; outputs")}},
+		},
+		{
+			name: "short exclude",
+			text: "- *.o",
+			want: []TokenValue{
+				{Token: Filter, Value: RsyncExclude, Line: util.Ptr("- *.o")},
+				{Token: Text, Value: "*.o", Line: util.Ptr("- *.o")},
+			},
+		},
+		{
+			name: "short include with modifier",
+			text: "+! /src/",
+			want: []TokenValue{
+				{Token: Filter, Value: RsyncInclude, Line: util.Ptr("+! /src/")},
+				{Token: Modifier, Value: "!", Line: util.Ptr("+! /src/")},
+				{Token: Text, Value: "/src/", Line: util.Ptr("+! /src/")},
+			},
+		},
+		{
+			name: "long protect with underscore",
+			text: "protect,s_core",
+			want: []TokenValue{
+				{Token: Filter, Value: RsyncProtect, Line: util.Ptr("protect,s_core")},
+				{Token: Modifier, Value: "s", Line: util.Ptr("protect,s_core")},
+				{Token: Text, Value: "core", Line: util.Ptr("protect,s_core")},
+			},
+		},
+		{
+			name: "pattern keeps spaces",
+			text: "H my file ",
+			want: []TokenValue{
+				{Token: Filter, Value: RsyncHide, Line: util.Ptr("H my file ")},
+				{Token: Text, Value: "my file ", Line: util.Ptr("H my file ")},
+			},
+		},
+		{
+			name: "merge",
+			text: "merge /etc/rsync/filters",
+			want: []TokenValue{{Token: Include, Value: "/etc/rsync/filters", Line: util.Ptr("merge /etc/rsync/filters")}},
+		},
+		{
+			name: "dir-merge",
+			text: ": .rsync-filter",
+			want: []TokenValue{{Token: DirMerge, Value: ".rsync-filter", Line: util.Ptr(": .rsync-filter")}},
+		},
+		{
+			name: "clear",
+			text: "!",
+			want: []TokenValue{{Token: Clear, Line: util.Ptr("!")}},
+		},
+		{name: "unknown rule", text: "ignore foo", wantErr: true},
+		{name: "unsupported modifier", text: "-/ /tmp", wantErr: true},
+		{name: "missing pattern", text: "-", wantErr: true},
+		{name: "merge modifiers", text: ":n- .filter", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rsyncFilterParser{}.ParseLine(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"io"
+	"strings"
+)
+
+// stignoreIncludeDirective includes the patterns of another file in a .stignore file
+const stignoreIncludeDirective = "#include"
+
+type stignoreParser struct {
+}
+
+// ParseAll contents from reader to a collection of TokenValue
+func (s stignoreParser) ParseAll(reader io.Reader) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(s)
+	return helpers.ParseAll(reader)
+}
+
+// ParseAllText from text input to a collection of TokenValue
+func (s stignoreParser) ParseAllText(text string) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(s)
+	return helpers.ParseAllText(text)
+}
+
+// ParseLine parses a line of text. A pattern may be preceded by any of the prefixes !, (?i) and (?d), which result in
+// Negate (always first), CaseInsensitive and Deletable tokens; a leading / results in a RootedMarker, and the rest
+// of the pattern is left as Text for the rule to interpret.
+func (s stignoreParser) ParseLine(text string) ([]TokenValue, error) {
+	parts := make([]TokenValue, 0)
+
+	line := strings.TrimSuffix(text, "\r")
+	if line == "" {
+		return parts, nil
+	}
+
+	// : Lines beginning with // are comments
+	if strings.HasPrefix(line, "//") {
+		commentText := strings.TrimSpace(strings.TrimPrefix(line, "//"))
+		parts = append(parts, TokenValue{Token: Comment, Value: commentText, Line: &text})
+		return parts, nil
+	}
+
+	if strings.HasPrefix(line, stignoreIncludeDirective) {
+		included := strings.TrimSpace(strings.TrimPrefix(line, stignoreIncludeDirective))
+		if included == "" {
+			return parts, newParsingError("include directive with no file")
+		}
+		parts = append(parts, TokenValue{Token: Include, Value: included, Line: &text})
+		return parts, nil
+	}
+
+	negated := false
+	flags := make([]TokenValue, 0)
+	seen := make(map[Token]bool)
+	for {
+		switch {
+		case strings.HasPrefix(line, string(Negate)) && !negated:
+			negated = true
+			line = strings.TrimPrefix(line, string(Negate))
+			continue
+		case strings.HasPrefix(line, string(CaseInsensitive)) && !seen[CaseInsensitive]:
+			seen[CaseInsensitive] = true
+			flags = append(flags, TokenValue{Token: CaseInsensitive, Line: &text})
+			line = strings.TrimPrefix(line, string(CaseInsensitive))
+			continue
+		case strings.HasPrefix(line, string(Deletable)) && !seen[Deletable]:
+			seen[Deletable] = true
+			flags = append(flags, TokenValue{Token: Deletable, Line: &text})
+			line = strings.TrimPrefix(line, string(Deletable))
+			continue
+		}
+		break
+	}
+
+	if negated {
+		parts = append(parts, TokenValue{Token: Negate, Line: &text})
+	}
+	parts = append(parts, flags...)
+
+	if strings.HasPrefix(line, string(RootedMarker)) {
+		parts = append(parts, TokenValue{Token: RootedMarker, Line: &text})
+		line = strings.TrimPrefix(line, string(RootedMarker))
+	}
+
+	if line == "" {
+		return parts, newParsingError("missing pattern")
+	}
+
+	parts = append(parts, TokenValue{Token: Text, Value: line, Line: &text})
+	return parts, nil
+}
+
+// NewStignoreParser is a strategy which parses Syncthing's .stignore text line-by-line
+func NewStignoreParser() Parser {
+	return stignoreParser{}
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Parser = &stignoreParser{}
+)
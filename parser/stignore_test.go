@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jimschubert/ignore/internal/util"
+)
+
+func TestStignoreParser_ParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []TokenValue
+		wantErr bool
+	}{
+		{
+			name: "comment",
+			text: "// temporary files",
+			want: []TokenValue{{Token: Comment, Value: "temporary files", Line: util.Ptr("// temporary files")}},
+		},
+		{
+			name: "empty",
+			text: "",
+			want: []TokenValue{},
+		},
+		{
+			name: "include",
+			text: "#include more-ignores",
+			want: []TokenValue{{Token: Include, Value: "more-ignores", Line: util.Ptr("#include more-ignores")}},
+		},
+		{
+			name: "hash is otherwise part of a pattern",
+			text: "#file",
+			want: []TokenValue{{Token: Text, Value: "#file", Line: util.Ptr("#file")}},
+		},
+		{
+			name: "prefixes in any order",
+			text: "(?d)(?i)!/Thumbs.db",
+			want: []TokenValue{
+				{Token: Negate, Line: util.Ptr("(?d)(?i)!/Thumbs.db")},
+				{Token: Deletable, Line: util.Ptr("(?d)(?i)!/Thumbs.db")},
+				{Token: CaseInsensitive, Line: util.Ptr("(?d)(?i)!/Thumbs.db")},
+				{Token: RootedMarker, Line: util.Ptr("(?d)(?i)!/Thumbs.db")},
+				{Token: Text, Value: "Thumbs.db", Line: util.Ptr("(?d)(?i)!/Thumbs.db")},
+			},
+		},
+		{
+			name: "repeated prefix is part of the pattern",
+			text: "!!a",
+			want: []TokenValue{
+				{Token: Negate, Line: util.Ptr("!!a")},
+				{Token: Text, Value: "!a", Line: util.Ptr("!!a")},
+			},
+		},
+		{name: "include without file", text: "#include", wantErr: true},
+		{name: "prefix without pattern", text: "(?i)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stignoreParser{}.ParseLine(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"io"
+	"strings"
+)
+
+// Subversion properties holding ignore patterns, carried as the Value of a Syntax token
+const (
+	// SvnIgnore patterns apply only to the entries of the directory on which the property is set
+	SvnIgnore = "svn:ignore"
+	// SvnGlobalIgnores patterns apply to entries at any depth beneath the directory on which the property is set
+	SvnGlobalIgnores = "svn:global-ignores"
+)
+
+type svnignoreParser struct {
+}
+
+// ParseAll contents from reader to a collection of TokenValue. Patterns are values of svn:ignore until a line naming
+// another property.
+func (s svnignoreParser) ParseAll(reader io.Reader) ([]TokenValue, error) {
+	helpers := NewParsingHelpers(&svnignoreSession{property: SvnIgnore})
+	return helpers.ParseAll(reader)
+}
+
+// ParseAllText from text input to a collection of TokenValue
+func (s svnignoreParser) ParseAllText(text string) ([]TokenValue, error) {
+	return s.ParseAll(strings.NewReader(text))
+}
+
+// ParseLine parses a single line of text as a value of svn:ignore; use ParseAll to carry a property across lines
+func (s svnignoreParser) ParseLine(text string) ([]TokenValue, error) {
+	return (&svnignoreSession{property: SvnIgnore}).ParseLine(text)
+}
+
+// svnignoreSession parses the lines of a single file, carrying the property whose values are listed
+type svnignoreSession struct {
+	property string
+}
+
+// ParseAll …
+func (s *svnignoreSession) ParseAll(reader io.Reader) ([]TokenValue, error) {
+	return NewParsingHelpers(s).ParseAll(reader)
+}
+
+// ParseAllText …
+func (s *svnignoreSession) ParseAllText(text string) ([]TokenValue, error) {
+	return NewParsingHelpers(s).ParseAllText(text)
+}
+
+// ParseLine parses a line of text. A line naming a property (svn:ignore or svn:global-ignores) results in a lone
+// Syntax token and applies to the lines which follow. Any other line lists whitespace separated patterns, as Subversion
+// splits property values, resulting in a Text token for each which are preceded by a Recursive token for values of
+// svn:global-ignores. There are no comments, as # is valid in a pattern.
+func (s *svnignoreSession) ParseLine(text string) ([]TokenValue, error) {
+	parts := make([]TokenValue, 0)
+
+	line := strings.TrimSpace(text)
+	switch line {
+	case "":
+		return parts, nil
+	case SvnIgnore, SvnGlobalIgnores:
+		s.property = line
+		parts = append(parts, TokenValue{Token: Syntax, Value: line, Line: &text})
+		return parts, nil
+	}
+
+	if s.property == SvnGlobalIgnores {
+		parts = append(parts, TokenValue{Token: Recursive, Line: &text})
+	}
+	for _, field := range strings.Fields(line) {
+		parts = append(parts, TokenValue{Token: Text, Value: field, Line: &text})
+	}
+	return parts, nil
+}
+
+// NewSvnignoreParser is a strategy which parses the values of Subversion's svn:ignore and svn:global-ignores
+// properties, with patterns separated by whitespace.
+func NewSvnignoreParser() Parser {
+	return svnignoreParser{}
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Parser = &svnignoreParser{}
+	_ Parser = &svnignoreSession{}
+)
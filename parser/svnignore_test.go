@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jimschubert/ignore/internal/util"
+)
+
+func TestSvnignoreParser_ParseAllText(t *testing.T) {
+	text := "*.o\n#*#\n\nsvn:global-ignores\n  *.cache  \n*.tmp\t*.swp\nsvn:ignore\ndist build"
+	got, err := NewSvnignoreParser().ParseAllText(text)
+	if err != nil {
+		t.Fatalf("ParseAllText() error = %v", err)
+	}
+
+	want := []TokenValue{
+		{Token: Text, Value: "*.o", Line: util.Ptr("*.o")},
+		NewLine,
+		{Token: Text, Value: "#*#", Line: util.Ptr("#*#")},
+		NewLine,
+		NewLine,
+		{Token: Syntax, Value: SvnGlobalIgnores, Line: util.Ptr("svn:global-ignores")},
+		NewLine,
+		{Token: Recursive, Line: util.Ptr("  *.cache  ")},
+		{Token: Text, Value: "*.cache", Line: util.Ptr("  *.cache  ")},
+		NewLine,
+		{Token: Recursive, Line: util.Ptr("*.tmp\t*.swp")},
+		{Token: Text, Value: "*.tmp", Line: util.Ptr("*.tmp\t*.swp")},
+		{Token: Text, Value: "*.swp", Line: util.Ptr("*.tmp\t*.swp")},
+		NewLine,
+		{Token: Syntax, Value: SvnIgnore, Line: util.Ptr("svn:ignore")},
+		NewLine,
+		{Token: Text, Value: "dist", Line: util.Ptr("dist build")},
+		{Token: Text, Value: "build", Line: util.Ptr("dist build")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseAllText() got = %v, want %v", got, want)
+	}
+}
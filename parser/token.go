@@ -26,6 +26,8 @@ func anyTokenMatch(value rune, t ...Token) bool {
 const (
 	MatchAll        Token = "**"
 	MatchAny        Token = "*"
+	Question        Token = "?"
+	CharClass       Token = "["
 	EscapedSpace    Token = "\\ "
 	Escape          Token = "\\"
 	PathDelim       Token = "/"
@@ -35,4 +37,15 @@ const (
 	RootedMarker    Token = "/"
 	Comment         Token = "#"
 	LineFeed        Token = "\n"
+	Syntax          Token = "syntax:"
+	Include         Token = "#!include:"
+	CaseInsensitive Token = "(?i)"
+	Deletable       Token = "(?d)"
+	Filter          Token = "filter"
+	Modifier        Token = "modifier"
+	DirMerge        Token = "dir-merge"
+	Clear           Token = "clear"
+	Command         Token = "command"
+	Recursive       Token = "recursive"
+	Alternative     Token = "{,}"
 )
@@ -0,0 +1,62 @@
+package ignore
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+)
+
+// patternsSource is reported by Explain as the source of rules defined in memory, rather than read from a file
+const patternsSource = "<patterns>"
+
+// WithPatterns is a functional option which defines rules from lines, each in the syntax of the strategy's ignore file.
+// See WithIgnoreBytes for how these rules combine with the ignore file(s).
+func WithPatterns(lines ...string) ProcessorOption {
+	return WithIgnoreBytes([]byte(strings.Join(lines, "\n")))
+}
+
+// WithIgnoreReader is a functional option which defines rules from the contents of reader, read in full when the
+// option is applied. See WithIgnoreBytes for how these rules combine with the ignore file(s).
+func WithIgnoreReader(reader io.Reader) ProcessorOption {
+	return func(processor *Processor) error {
+		if reader == nil {
+			return errors.New("reader must not be nil")
+		}
+
+		contents, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+
+		return WithIgnoreBytes(contents)(processor)
+	}
+}
+
+// WithIgnoreBytes is a functional option which defines rules from contents, as if read from an ignore file, such as
+// defaults embedded in a binary. Include directives are resolved relative to the ignore file's directory.
+//
+// Rules defined in memory are applied to the root ahead of (with lower precedence than) the ignore file(s), in the
+// order given. Unless an ignore file is named, e.g. by WithIgnoreFilePath or WithNestedIgnoreFiles, the strategy's
+// default ignore file is not read.
+func WithIgnoreBytes(contents []byte) ProcessorOption {
+	return func(processor *Processor) error {
+		processor.patterns = append(processor.patterns, append([]byte(nil), contents...))
+		return nil
+	}
+}
+
+// processPatterns adds a rule set for each of the Processor's in-memory definitions
+func (p *Processor) processPatterns() error {
+	for _, contents := range p.patterns {
+		ruleList, err := p.parseRules(bytes.NewReader(contents), p.includer(p.root(), p.open, nil))
+		if err != nil {
+			return err
+		}
+
+		if err := p.appendRuleSet(ruleSet{source: patternsSource, rules: ruleList, fsys: p.scope("")}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/jimschubert/ignore/parser"
+	"github.com/jimschubert/ignore/strategy"
+	"github.com/jimschubert/ignore/test"
+)
+
+func TestWithPatterns(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".gitignore":      "!debug.log\nbuild/\n",
+		".gcloudignore":   "",
+		"nested/.keep":    "",
+		"config/defaults": "*.tmp\n",
+	})
+
+	tests := []struct {
+		name       string
+		opts       []ProcessorOption
+		conditions []AllowTestCondition
+		wantErr    bool
+	}{
+		{
+			name: "patterns alone",
+			opts: []ProcessorOption{WithPatterns("*.log", "!keep.log")},
+			conditions: []AllowTestCondition{
+				{File: "debug.log", Allows: false},
+				{File: "keep.log", Allows: true},
+				{File: "main.go", Allows: true},
+			},
+		},
+		{
+			name: "reader",
+			opts: []ProcessorOption{WithIgnoreReader(strings.NewReader("*.log\n!keep.log\n"))},
+			conditions: []AllowTestCondition{
+				{File: "debug.log", Allows: false},
+				{File: "keep.log", Allows: true},
+			},
+		},
+		{
+			name: "bytes",
+			opts: []ProcessorOption{WithIgnoreBytes([]byte("*.log\n"))},
+			conditions: []AllowTestCondition{
+				{File: "debug.log", Allows: false},
+				{File: "main.go", Allows: true},
+			},
+		},
+		{
+			name: "ignore file takes precedence over patterns",
+			opts: []ProcessorOption{
+				WithPatterns("*.log"),
+				WithIgnoreFilePath(filepath.Join(root, ".gitignore")),
+			},
+			conditions: []AllowTestCondition{
+				{File: "app.log", Allows: false},
+				{File: "debug.log", Allows: true},
+				{File: "build/", Allows: false},
+			},
+		},
+		{
+			name: "later patterns take precedence",
+			opts: []ProcessorOption{WithPatterns("*.log"), WithPatterns("!debug.log")},
+			conditions: []AllowTestCondition{
+				{File: "app.log", Allows: false},
+				{File: "debug.log", Allows: true},
+			},
+		},
+		{
+			name: "optional ignore file which is missing",
+			opts: []ProcessorOption{
+				WithPatterns("*.log"),
+				WithOptionalIgnoreFilePath(filepath.Join(root, "nested", ".gitignore")),
+			},
+			conditions: []AllowTestCondition{
+				{File: "app.log", Allows: false},
+				{File: "main.go", Allows: true},
+			},
+		},
+		{
+			name: "optional ignore file which exists",
+			opts: []ProcessorOption{
+				WithPatterns("*.log"),
+				WithOptionalIgnoreFilePath(filepath.Join(root, ".gitignore")),
+			},
+			conditions: []AllowTestCondition{
+				{File: "app.log", Allows: false},
+				{File: "debug.log", Allows: true},
+			},
+		},
+		{
+			name: "include relative to the ignore file",
+			opts: []ProcessorOption{
+				WithGcloudignoreStrategy(),
+				WithPatterns("#!include:config/defaults"),
+				WithIgnoreFilePath(filepath.Join(root, ".gcloudignore")),
+			},
+			conditions: []AllowTestCondition{
+				{File: "a.tmp", Allows: false},
+				{File: "a.txt", Allows: true},
+			},
+		},
+		{
+			name: "custom strategy without an ignore file",
+			opts: []ProcessorOption{
+				WithStrategy(strategy.New(filepath.Join(t.TempDir(), ".gitignore"), parser.NewGitignoreParser(), rootedRuleBuilder)),
+				WithPatterns("*.log"),
+			},
+			conditions: []AllowTestCondition{
+				{File: "app.log", Allows: false},
+				{File: "main.go", Allows: true},
+			},
+		},
+		{
+			name: "named strategy without an ignore file",
+			opts: []ProcessorOption{
+				WithStrategyNamed("gitignore"),
+				WithPatterns("*.log"),
+			},
+			conditions: []AllowTestCondition{
+				{File: "app.log", Allows: false},
+				{File: "main.go", Allows: true},
+			},
+		},
+		{
+			name:    "missing ignore file",
+			opts:    []ProcessorOption{WithPatterns("*.log"), WithIgnoreFilePath(filepath.Join(root, "nested", ".gitignore"))},
+			wantErr: true,
+		},
+		{
+			name:    "nil reader",
+			opts:    []ProcessorOption{WithIgnoreReader(nil)},
+			wantErr: true,
+		},
+		{
+			name:    "failing reader",
+			opts:    []ProcessorOption{WithIgnoreReader(iotest.ErrReader(os.ErrClosed))},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := NewProcessor(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewProcessor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			assertConditions(t, processor, tt.conditions)
+		})
+	}
+}
+
+func TestWithPatterns_explain(t *testing.T) {
+	processor, err := NewProcessor(WithPatterns("# defaults", "*.log"))
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	match, err := processor.ExplainPath("debug.log", false)
+	if err != nil {
+		t.Fatalf("ExplainPath() error = %v", err)
+	}
+	if match.Source != patternsSource || match.Line != 2 || match.Pattern != "*.log" {
+		t.Errorf("ExplainPath() = %s:%d:%s, want %s:2:*.log", match.Source, match.Line, match.Pattern, patternsSource)
+	}
+}
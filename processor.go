@@ -2,19 +2,35 @@ package ignore
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/jimschubert/ignore/internal/strategies"
+	"github.com/jimschubert/ignore/internal/util"
 	"github.com/jimschubert/ignore/parser"
 	"github.com/jimschubert/ignore/rules"
 	"github.com/jimschubert/ignore/strategy"
 )
 
 type Processor struct {
-	strategy    strategy.Strategy
-	ruleList    []rules.Rule
-	initialized bool
+	strategy   strategy.Strategy
+	fsys       fs.FS
+	ruleSets   []ruleSet
+	nestedRoot string
+	// excludeFiles are applied to the root, ahead of (with lower precedence than) the ignore file(s)
+	excludeFiles []string
+	// patterns are definitions held in memory, applied to the root after excludeFiles and ahead of the ignore file(s)
+	patterns [][]byte
+	// ignoreFilePath is named by an option (e.g. WithIgnoreFilePath), rather than relying on the definition path of the
+	// strategy. It's resolved once every option has been applied.
+	ignoreFilePath string
+	// optionalIgnoreFile treats a missing ignore file as one without any rules
+	optionalIgnoreFile bool
+	initialized        bool
 }
 
 func (p *Processor) processIgnoreFile() error {
@@ -22,30 +38,333 @@ func (p *Processor) processIgnoreFile() error {
 		return nil
 	}
 
+	if err := p.loadRuleSets(); err != nil {
+		// nothing is evaluated against a partial set of rules, and a later call tries again
+		p.ruleSets = make([]ruleSet, 0)
+		return err
+	}
+
 	p.initialized = true
+	return nil
+}
+
+// loadRuleSets reads the rule sets of every source, in order of increasing precedence
+func (p *Processor) loadRuleSets() error {
+	for _, excludeFile := range p.excludeFiles {
+		// excludes are always read from the operating system
+		ruleList, err := p.readRules(excludeFile, osOpen)
+		if err != nil {
+			return err
+		}
+
+		p.ruleSets = append(p.ruleSets, ruleSet{source: excludeFile, rules: ruleList, fsys: p.scope("")})
+	}
+
+	if err := p.processPatterns(); err != nil {
+		return err
+	}
+
+	presets, err := p.presets(p.root())
+	if err != nil {
+		return err
+	}
+
+	if err := p.appendPresets(presets, presets.Before); err != nil {
+		return err
+	}
+
+	if p.nestedRoot != "" {
+		err = p.processNestedIgnoreFiles(presets.ReplacesRoot)
+	} else if !presets.ReplacesRoot && (len(p.patterns) == 0 || p.ignoreFilePath != "") {
+		err = p.processRootIgnoreFile()
+	}
+	if err != nil {
+		return err
+	}
+
+	return p.appendPresets(presets, presets.After)
+}
+
+// processRootIgnoreFile reads the ignore file at the strategy's definition path
+func (p *Processor) processRootIgnoreFile() error {
 	ignoreFile := p.strategy.DefinitionPath()
-	file, err := os.Open(ignoreFile)
+	if strategies.HasFallbacks(p.strategy) || p.optionalIgnoreFile {
+		found, err := p.findIgnoreFile(filepath.Dir(ignoreFile), filepath.Base(ignoreFile))
+		if err != nil || found == "" {
+			return err
+		}
+		ignoreFile = found
+	}
+
+	ruleList, err := p.readRules(ignoreFile, p.open)
 	if err != nil {
 		return err
 	}
-	defer func(file *os.File) {
+
+	return p.appendRuleSet(ruleSet{source: ignoreFile, rules: ruleList, fsys: p.scope("")})
+}
+
+// root is the directory containing the root ignore file
+func (p *Processor) root() string {
+	if p.nestedRoot != "" {
+		return p.nestedRoot
+	}
+	return filepath.Dir(p.strategy.DefinitionPath())
+}
+
+// appendRuleSet adds set to the Processor, expanding each per-directory merge directive (e.g. rsync's dir-merge)
+// into a rule set for every directory beneath set's base which contains the named file. Those rule sets take the
+// position of the directive, deepest first, so that under first-match precedence a deeper file overrides its parents.
+func (p *Processor) appendRuleSet(set ruleSet) error {
+	current := set
+	current.rules = make([]definedRule, 0, len(set.rules))
+	for _, r := range set.rules {
+		if r.dirMerge == "" {
+			current.rules = append(current.rules, r)
+			continue
+		}
+
+		if len(current.rules) > 0 {
+			p.ruleSets = append(p.ruleSets, current)
+		}
+
+		merged, err := p.dirMergeRuleSets(set.base, r.dirMerge)
+		if err != nil {
+			return err
+		}
+		p.ruleSets = append(p.ruleSets, merged...)
+
+		current = set
+		current.rules = make([]definedRule, 0)
+	}
+
+	p.ruleSets = append(p.ruleSets, current)
+	return nil
+}
+
+// dirMergeRuleSets reads each file named name within base (relative to the root) and its subdirectories, providing
+// the rule sets deepest first. Per-directory merge directives within those files are not expanded.
+func (p *Processor) dirMergeRuleSets(base string, name string) ([]ruleSet, error) {
+	root := p.root()
+	sets := make([]ruleSet, 0)
+	err := p.walkDir(p.join(root, base), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		dir, err := p.relativeTo(root, path)
+		if err != nil {
+			return err
+		}
+		if dir == "." {
+			dir = ""
+		}
+
+		mergeFile, err := p.findIgnoreFile(path, name)
+		if err != nil || mergeFile == "" {
+			return err
+		}
+
+		ruleList, err := p.readRules(mergeFile, p.open)
+		if err != nil {
+			return err
+		}
+
+		sets = append([]ruleSet{{base: dir, source: mergeFile, rules: ruleList, fsys: p.scope(dir)}}, sets...)
+		return nil
+	})
+	return sets, err
+}
+
+// presets provides the definitions contributed by a strategy implementing strategy.Preset, reading supporting files
+// from root
+func (p *Processor) presets(root string) (strategy.Presets, error) {
+	preset, ok := p.strategy.(strategy.Preset)
+	if !ok {
+		return strategy.Presets{}, nil
+	}
+
+	return preset.Presets(func(name string) (io.ReadCloser, error) {
+		return p.open(p.join(root, name))
+	})
+}
+
+// appendPresets adds a rule set for definitions (either Before or After of presets) contributed by the strategy
+func (p *Processor) appendPresets(presets strategy.Presets, definitions string) error {
+	if definitions == "" {
+		return nil
+	}
+
+	ruleList, err := p.parseRules(strings.NewReader(definitions), nil)
+	if err != nil {
+		return err
+	}
+
+	p.ruleSets = append(p.ruleSets, ruleSet{
+		source:         presets.Source,
+		rules:          ruleList,
+		fsys:           p.scope(""),
+		everyDirectory: presets.EveryDirectory,
+	})
+	return nil
+}
+
+// findIgnoreFile locates the ignore file named name within dir or, when absent, the first of the strategy's
+// fallbacks which exists. An empty result indicates that dir contains no ignore file.
+func (p *Processor) findIgnoreFile(dir string, name string) (string, error) {
+	candidates := []string{name}
+	if fallback, ok := p.strategy.(strategy.Fallback); ok {
+		candidates = append(candidates, fallback.FallbackNames()...)
+	}
+
+	for _, candidate := range candidates {
+		ignoreFile := p.join(dir, candidate)
+		fileInfo, err := p.stat(ignoreFile)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+			continue
+		}
+
+		if !fileInfo.IsDir() {
+			return ignoreFile, nil
+		}
+	}
+
+	return "", nil
+}
+
+// processNestedIgnoreFiles discovers every ignore file beneath nestedRoot sharing the base name of the strategy's
+// definition path. Directories are visited parent-first, so deeper ignore files are evaluated later and take precedence.
+// When skipRoot is set, the ignore file in nestedRoot itself is not read.
+func (p *Processor) processNestedIgnoreFiles(skipRoot bool) error {
+	name := filepath.Base(p.strategy.DefinitionPath())
+	return p.walkDir(p.nestedRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		base, err := p.relativeTo(p.nestedRoot, path)
+		if err != nil {
+			return err
+		}
+
+		if base == "." {
+			base = ""
+		} else {
+			// ignore files within an excluded directory are never read
+			allowed, err := p.allows(base, util.Ptr(true))
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return fs.SkipDir
+			}
+		}
+
+		if base == "" && skipRoot {
+			return nil
+		}
+
+		ignoreFile, err := p.findIgnoreFile(path, name)
+		if err != nil || ignoreFile == "" {
+			return err
+		}
+
+		ruleList, err := p.readRules(ignoreFile, p.open)
+		if err != nil {
+			return err
+		}
+
+		return p.appendRuleSet(ruleSet{base: base, source: ignoreFile, rules: ruleList, fsys: p.scope(base)})
+	})
+}
+
+// osOpen opens the named file from the operating system's file system
+func osOpen(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+// readRules parses ignoreFile, opened with open, and builds a rule for each of its lines. The rules of any file
+// included by ignoreFile (e.g. via #!include in .gcloudignore) are spliced in place of the include directive.
+func (p *Processor) readRules(ignoreFile string, open func(name string) (fs.File, error)) ([]definedRule, error) {
+	return p.readIncludedRules(ignoreFile, open, nil)
+}
+
+// readIncludedRules reads ignoreFile as readRules does, where includedBy lists the files (outermost first) which
+// included ignoreFile, so that an include cycle can be detected.
+func (p *Processor) readIncludedRules(ignoreFile string, open func(name string) (fs.File, error), includedBy []string) ([]definedRule, error) {
+	chain := append(append(make([]string, 0, len(includedBy)+1), includedBy...), p.join(ignoreFile))
+	for _, including := range includedBy {
+		if including == p.join(ignoreFile) {
+			return nil, fmt.Errorf("include cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+
+	file, err := open(ignoreFile)
+	if err != nil {
+		return nil, err
+	}
+	defer func(file fs.File) {
 		_ = file.Close()
 	}(file)
 
-	parts, err := p.strategy.Parser().ParseAll(file)
+	// : included files are resolved relative to the including file
+	return p.parseRules(file, p.includer(filepath.Dir(ignoreFile), open, chain))
+}
+
+// includer resolves include directives relative to dir, where chain lists the files (outermost first) including them
+func (p *Processor) includer(dir string, open func(name string) (fs.File, error), chain []string) func(included string) ([]definedRule, error) {
+	return func(included string) ([]definedRule, error) {
+		includedFile := p.join(dir, included)
+		ruleList, err := p.readIncludedRules(includedFile, open, chain)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range ruleList {
+			if ruleList[i].source == "" {
+				ruleList[i].source = includedFile
+			}
+		}
+		return ruleList, nil
+	}
+}
+
+// parseRules parses definitions from reader, and builds a rule for each of its lines. Include directives are
+// resolved with include, and are an error when include is nil.
+func (p *Processor) parseRules(reader io.Reader, include func(included string) ([]definedRule, error)) ([]definedRule, error) {
+	parts, err := p.strategy.Parser().ParseAll(reader)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	ruleList := make([]definedRule, 0)
+	line := 1
 	maxLen := len(parts)
 	for i := 0; i < maxLen; i++ {
 		if parts[i].Token == parser.LineFeed {
+			line++
+			continue
+		}
+
+		// : each pattern resulting from brace expansion defines a rule of the same line
+		if parts[i].Token == parser.Alternative {
 			continue
 		}
 
 		width := 0
 		for _, value := range parts[i:] {
-			if value.Token == parser.LineFeed {
+			if value.Token == parser.LineFeed || value.Token == parser.Alternative {
 				break
 			}
 			width += 1
@@ -55,54 +374,228 @@ func (p *Processor) processIgnoreFile() error {
 			continue
 		}
 
+		switch parts[i].Token {
+		case parser.Clear:
+			// : a clear directive discards the decision of any rule preceding it. The rule built for the directive,
+			// if it's an EvaluatingRule, limits the paths affected.
+			marker, err := p.strategy.RuleBuilder().RuleFor(parts[i : i+1])
+			if err != nil {
+				return nil, err
+			}
+
+			ruleList = append(ruleList, definedRule{Rule: marker, line: line, clear: true})
+			if width == 1 {
+				continue
+			}
+
+			// any remaining tokens on the line define a rule
+			i++
+			width--
+		case parser.DirMerge:
+			marker, err := rules.NewEmptyRule(util.StringValue(parts[i].Line), parts[i:i+width])
+			if err != nil {
+				return nil, err
+			}
+
+			ruleList = append(ruleList, definedRule{Rule: marker, line: line, dirMerge: parts[i].Value})
+			i += width - 1
+			continue
+		}
+
+		if parts[i].Token == parser.Include {
+			if include == nil {
+				return nil, fmt.Errorf("line %d: include directives are not supported here", line)
+			}
+
+			included, err := include(parts[i].Value)
+			if err != nil {
+				return nil, err
+			}
+
+			ruleList = append(ruleList, included...)
+			i += width - 1
+			continue
+		}
+
 		var rule rules.Rule
 
 		// TODO: Decide if definition is really need here
 		if rule, err = p.strategy.RuleBuilder().RuleFor(parts[i : i+width]); err != nil {
-			return err
+			return nil, err
 		}
 
-		p.ruleList = append(p.ruleList, rule)
+		ruleList = append(ruleList, definedRule{Rule: rule, line: line})
 
-		i += width
+		// the line feed (or alternative) terminating this rule is visited next
+		i += width - 1
 	}
 
-	return nil
+	return ruleList, nil
 }
 
+// AllowsFile determines whether path is allowed by the ignore rules. Whether path is a directory is determined by
+// consulting the file system; see AllowsPath for callers which already know.
+//
+// A relative path is relative to the directory containing the ignore file (regardless of the process working
+// directory), and may begin with ./ or contain .. elements. An absolute path must be within that directory, otherwise
+// the error is ErrOutsideBase.
 func (p *Processor) AllowsFile(path string) (bool, error) {
 	if err := p.processIgnoreFile(); err != nil {
 		return true, err
 	}
 
-	exclude := false
-	hasIncludes := false
-	for _, rule := range p.ruleList {
-		switch r := rule.(type) {
-		case rules.EvaluatingRule:
-			op, err := r.Evaluate(path)
-			if err != nil {
-				return false, err
-			}
+	relativePath, err := p.relative(path)
+	if err != nil {
+		return false, err
+	}
+
+	return p.allows(relativePath, nil)
+}
 
-			if op == rules.ExcludeAndTerminate {
-				return false, err
+// AllowsPath determines whether path is allowed by the ignore rules, where isDir states whether path is a directory.
+// The file system is never consulted, so path need not exist (e.g. an entry in an archive or a git tree). Paths are
+// interpreted as they are by AllowsFile.
+func (p *Processor) AllowsPath(path string, isDir bool) (bool, error) {
+	if err := p.processIgnoreFile(); err != nil {
+		return true, err
+	}
+
+	relativePath, err := p.relative(path)
+	if err != nil {
+		return false, err
+	}
+
+	return p.allows(relativePath, &isDir)
+}
+
+// allows determines whether path is allowed by the rules loaded so far. When isDir is nil, the file system is
+// consulted to determine whether path is a directory.
+func (p *Processor) allows(path string, isDir *bool) (bool, error) {
+	d, err := p.decide(path, isDir)
+	if err != nil {
+		return false, err
+	}
+
+	return allowedBy(d.op), nil
+}
+
+// decide determines the operation for path, and the rule responsible for it, from the rules loaded so far
+func (p *Processor) decide(path string, isDir *bool) (decision, error) {
+	// : the base directory itself is never ignored
+	if path == "." {
+		return decision{op: rules.Noop}, nil
+	}
+
+	// : It is not possible to re-include a file if a parent directory of that file is excluded.
+	for _, parent := range parentDirectories(path) {
+		d, err := p.evaluate(parent, util.Ptr(true))
+		if err != nil {
+			return decision{op: rules.Invalid}, err
+		}
+
+		if d.op == rules.ExcludeAndTerminate {
+			return d, nil
+		}
+	}
+
+	return p.evaluate(path, isDir)
+}
+
+// allowedBy determines whether the deciding operation for a path allows that path
+func allowedBy(op rules.Operation) bool {
+	return op != rules.Exclude && op != rules.ExcludeAndTerminate
+}
+
+// evaluate returns the decision of the last rule matching path, with an operation of rules.Noop if no rule matches.
+// As with git, the order of rules matters: a later matching rule overrides any earlier decision, and rules
+// from a deeper ignore file override those from its parent directories. For a strategy implementing
+// strategy.Precedence, the first matching rule may decide instead.
+//
+// When isDir is nil, each rule set's file system is consulted to determine whether path is a directory.
+func (p *Processor) evaluate(path string, isDir *bool) (decision, error) {
+	firstMatch := false
+	if precedence, ok := p.strategy.(strategy.Precedence); ok {
+		firstMatch = precedence.FirstMatchWins()
+	}
+
+	result := decision{op: rules.Noop}
+	for i := range p.ruleSets {
+		set := &p.ruleSets[i]
+		relativePath, ok := set.relativePath(path)
+		if !ok {
+			continue
+		}
+
+		for j := range set.rules {
+			if set.rules[j].clear {
+				applies, err := p.clears(set.rules[j].Rule, set.fsys, relativePath, isDir)
+				if err != nil {
+					return decision{op: rules.Invalid}, err
+				}
+				if applies {
+					result = decision{op: rules.Noop}
+				}
+				continue
 			}
 
-			// invalid rules will not impact include/exclude analysis.
-			if op != rules.Invalid {
-				exclude = exclude || op == rules.Exclude
-				hasIncludes = hasIncludes || op == rules.Include
+			switch r := set.rules[j].Rule.(type) {
+			case rules.EvaluatingRule:
+				op, err := evaluateRule(r, set.fsys, relativePath, isDir)
+				if err != nil {
+					return decision{op: rules.Invalid}, err
+				}
+
+				// invalid rules will not impact include/exclude analysis.
+				if op != rules.Invalid && op != rules.Noop {
+					// under first-match precedence, only a clear directive overrides an earlier decision
+					if !firstMatch || result.op == rules.Noop {
+						result = decision{op: op, set: set, rule: &set.rules[j]}
+					}
+				}
 			}
 		}
 	}
 
-	// regardless of any combination… if user explicitly includes anywhere it will include.
-	if exclude && hasIncludes {
-		return true, nil
+	return result, nil
+}
+
+// clears determines whether a clear directive, whose rule is r, applies to path. A directive whose rule can't be
+// evaluated applies to every path.
+func (p *Processor) clears(r rules.Rule, fsys fs.FS, path string, isDir *bool) (bool, error) {
+	if pathRule, ok := r.(rules.PathEvaluatingRule); ok && isDir != nil {
+		return pathRule.AppliesToPath(path, *isDir), nil
+	}
+	if fsRule, ok := r.(rules.FSEvaluatingRule); ok {
+		return fsRule.AppliesToFS(fsys, path), nil
+	}
+	if evaluating, ok := r.(rules.EvaluatingRule); ok {
+		return evaluating.AppliesTo(path), nil
+	}
+	return true, nil
+}
+
+// evaluateRule evaluates r against path, using the most specific method r supports: EvaluatePath when the type of
+// path is known, then EvaluateFS, then Evaluate.
+func evaluateRule(r rules.EvaluatingRule, fsys fs.FS, path string, isDir *bool) (rules.Operation, error) {
+	if pathRule, ok := r.(rules.PathEvaluatingRule); ok && isDir != nil {
+		return pathRule.EvaluatePath(path, *isDir)
+	}
+	if fsRule, ok := r.(rules.FSEvaluatingRule); ok {
+		return fsRule.EvaluateFS(fsys, path)
 	}
+	return r.Evaluate(path)
+}
 
-	return !exclude, nil
+// parentDirectories lists the parent directories of path, outermost first. Each entry retains its trailing
+// separator so that it is evaluated as a directory.
+func parentDirectories(path string) []string {
+	parents := make([]string, 0)
+	for i := 1; i < len(path)-1; i++ {
+		if path[i] == filepath.Separator {
+			parents = append(parents, path[:i+1])
+		}
+	}
+	return parents
 }
 
 type ProcessorOption func(*Processor) error
@@ -115,26 +608,81 @@ func WithGitignoreStrategy() ProcessorOption {
 	}
 }
 
-// WithIgnoreFilePath is a functional option which allows the user to parse a non-standard filename for a given strategy
-func WithIgnoreFilePath(filePath string) ProcessorOption {
+// WithStrategy is a functional option which applies a custom strategy, such as one composed via strategy.New
+func WithStrategy(s strategy.Strategy) ProcessorOption {
 	return func(processor *Processor) error {
+		if s == nil {
+			return errors.New("strategy must not be nil")
+		}
+
+		processor.strategy = s
+		return nil
+	}
+}
+
+// WithStrategyNamed is a functional option which applies the strategy registered under name (see strategy.Register),
+// such as "gitignore" or "dockerignore"
+func WithStrategyNamed(name string) ProcessorOption {
+	return func(processor *Processor) error {
+		s, ok := strategy.Lookup(name)
+		if !ok {
+			return fmt.Errorf("no strategy registered under the name %q (expected one of %s)", name, strings.Join(strategy.Names(), ", "))
+		}
+
+		processor.strategy = s
+		return nil
+	}
+}
+
+// WithStrategyForFile is a functional option which applies the strategy registered for the base name of filePath
+// (see strategy.Register), then reads the ignore file at filePath as with WithIgnoreFilePath. For example, a path
+// ending in .dockerignore selects the dockerignore strategy.
+func WithStrategyForFile(filePath string) ProcessorOption {
+	return func(processor *Processor) error {
+		s, ok := strategy.ForFile(filePath)
+		if !ok {
+			return fmt.Errorf("no strategy registered for ignore files named %q", filepath.Base(filePath))
+		}
+
+		processor.strategy = s
+		return WithIgnoreFilePath(filePath)(processor)
+	}
+}
+
+// WithParser is a functional option which replaces the parser of the current strategy
+func WithParser(p parser.Parser) ProcessorOption {
+	return func(processor *Processor) error {
+		if p == nil {
+			return errors.New("parser must not be nil")
+		}
+
 		m, err := strategies.AsMutable(processor.strategy)
 		if err != nil {
 			return err
 		}
 
-		var fullPath string
-		fullPath, _ = filepath.Abs(filePath)
-		fileInfo, err := os.Stat(fullPath)
-		if err != nil {
+		if err := m.SetParser(p); err != nil {
 			return err
 		}
 
-		if fileInfo.IsDir() {
-			return errors.New("ignore file path must be a regular file, not a directory")
+		processor.strategy = m
+		return nil
+	}
+}
+
+// WithRuleBuilder is a functional option which replaces the rule builder of the current strategy
+func WithRuleBuilder(b strategy.RuleBuilder) ProcessorOption {
+	return func(processor *Processor) error {
+		if b == nil {
+			return errors.New("rule builder must not be nil")
+		}
+
+		m, err := strategies.AsMutable(processor.strategy)
+		if err != nil {
+			return err
 		}
 
-		if err := m.SetDefinitionPath(fullPath); err != nil {
+		if err := m.SetRuleBuilder(b); err != nil {
 			return err
 		}
 
@@ -143,12 +691,187 @@ func WithIgnoreFilePath(filePath string) ProcessorOption {
 	}
 }
 
+// WithFS is a functional option which reads ignore files from fsys, and consults fsys when determining whether an
+// evaluated path is a directory. As without fsys, paths given to the Processor are relative to the directory of the
+// ignore file (or the root given to WithNestedIgnoreFiles) within fsys. Files and directories named by other options,
+// in any order, are resolved within fsys.
+func WithFS(fsys fs.FS) ProcessorOption {
+	return func(processor *Processor) error {
+		if fsys == nil {
+			return errors.New("file system must not be nil")
+		}
+
+		processor.fsys = fsys
+		return nil
+	}
+}
+
+// WithDockerignoreStrategy is a functional option which applies the strategy for parsing .dockerignore files
+func WithDockerignoreStrategy() ProcessorOption {
+	return func(processor *Processor) error {
+		processor.strategy = strategies.DockerignoreStrategy()
+		return nil
+	}
+}
+
+// WithGcloudignoreStrategy is a functional option which applies the strategy for parsing .gcloudignore files, whose
+// #!include directives splice in the rules of another file (resolved relative to the including file).
+func WithGcloudignoreStrategy() ProcessorOption {
+	return func(processor *Processor) error {
+		processor.strategy = strategies.GcloudignoreStrategy()
+		return nil
+	}
+}
+
+// WithHgignoreStrategy is a functional option which applies the strategy for parsing Mercurial's .hgignore files
+func WithHgignoreStrategy() ProcessorOption {
+	return func(processor *Processor) error {
+		processor.strategy = strategies.HgignoreStrategy()
+		return nil
+	}
+}
+
+// WithStignoreStrategy is a functional option which applies the strategy for parsing Syncthing's .stignore files.
+// The first matching rule decides a path's outcome, and #include directives splice in the rules of another file.
+func WithStignoreStrategy() ProcessorOption {
+	return func(processor *Processor) error {
+		processor.strategy = strategies.StignoreStrategy()
+		return nil
+	}
+}
+
+// WithRsyncFilterStrategy is a functional option which applies the strategy for parsing rsync filter rules, predicting
+// which files rsync would transfer. The first matching rule decides a path's outcome, merge rules splice in another
+// file, and dir-merge rules read the named file from each directory, applying its rules to that directory's contents.
+func WithRsyncFilterStrategy() ProcessorOption {
+	return func(processor *Processor) error {
+		processor.strategy = strategies.RsyncFilterStrategy()
+		return nil
+	}
+}
+
+// WithManifestStrategy is a functional option which applies the strategy for Python's MANIFEST.in, predicting which
+// files an sdist will contain. Unlike an ignore file, paths are excluded unless selected by a command such as include,
+// recursive-include or graft; setuptools' default files are always included, and build or VCS directories excluded.
+func WithManifestStrategy() ProcessorOption {
+	return func(processor *Processor) error {
+		processor.strategy = strategies.ManifestStrategy()
+		return nil
+	}
+}
+
+// WithCvsignoreStrategy is a functional option which applies the strategy for parsing CVS's .cvsignore files. Patterns
+// apply only to the entries of the directory containing the .cvsignore (see WithNestedIgnoreFiles), after CVS's
+// default ignore list; a lone ! clears the patterns accumulated so far, including the defaults.
+func WithCvsignoreStrategy() ProcessorOption {
+	return func(processor *Processor) error {
+		processor.strategy = strategies.CvsignoreStrategy()
+		return nil
+	}
+}
+
+// WithSvnignoreStrategy is a functional option which applies the strategy for Subversion's svn:ignore and
+// svn:global-ignores properties, with the values of each directory's properties stored in its own .svnignore file
+// (see WithNestedIgnoreFiles). Values of svn:ignore apply only to the entries of that directory, while values listed
+// after an svn:global-ignores line apply at any depth. Subversion's default global-ignores always apply.
+func WithSvnignoreStrategy() ProcessorOption {
+	return func(processor *Processor) error {
+		processor.strategy = strategies.SvnignoreStrategy()
+		return nil
+	}
+}
+
+// WithNpmignoreStrategy is a functional option which applies the strategy for parsing .npmignore files. As with
+// npm pack, .gitignore is read in place of a missing .npmignore, a "files" list in package.json acts as an allowlist,
+// and files such as package.json and README are always included while .git and node_modules are always excluded.
+func WithNpmignoreStrategy() ProcessorOption {
+	return func(processor *Processor) error {
+		processor.strategy = strategies.NpmignoreStrategy()
+		return nil
+	}
+}
+
+// WithIgnoreFilePath is a functional option which allows the user to parse a non-standard filename for a given strategy.
+// The file must exist, unless the strategy falls back to other ignore files (e.g. npm's use of .gitignore).
+func WithIgnoreFilePath(filePath string) ProcessorOption {
+	return withIgnoreFilePath(filePath, false)
+}
+
+// WithOptionalIgnoreFilePath is a functional option like WithIgnoreFilePath, but a missing file is treated as an empty
+// one, e.g. for a user's ignore file merged with defaults from WithPatterns.
+func WithOptionalIgnoreFilePath(filePath string) ProcessorOption {
+	return withIgnoreFilePath(filePath, true)
+}
+
+func withIgnoreFilePath(filePath string, optional bool) ProcessorOption {
+	return func(processor *Processor) error {
+		if filePath == "" {
+			return errors.New("ignore file path must not be empty")
+		}
+
+		processor.ignoreFilePath = filePath
+		processor.optionalIgnoreFile = optional
+		return nil
+	}
+}
+
+// resolveIgnoreFilePath validates the ignore file path named by an option, within the file system of WithFS if any,
+// and makes it the strategy's definition path
+func (p *Processor) resolveIgnoreFilePath() error {
+	m, err := strategies.AsMutable(p.strategy)
+	if err != nil {
+		return err
+	}
+
+	fullPath := p.ignoreFilePath
+	if p.fsys == nil {
+		fullPath, _ = filepath.Abs(p.ignoreFilePath)
+	}
+	fileInfo, err := p.stat(fullPath)
+	if err != nil {
+		// a strategy with fallbacks may read another file in the same directory, if any
+		if (!strategies.HasFallbacks(m) && !p.optionalIgnoreFile) || !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	} else if fileInfo.IsDir() {
+		return errors.New("ignore file path must be a regular file, not a directory")
+	}
+
+	if err := m.SetDefinitionPath(fullPath); err != nil {
+		return err
+	}
+
+	p.strategy = m
+	return nil
+}
+
+// WithNestedIgnoreFiles is a functional option which loads every ignore file found in root and its subdirectories.
+// Each ignore file applies to paths within its own directory, and files deeper in the tree take precedence.
+// Paths evaluated by the Processor are then relative to root.
+func WithNestedIgnoreFiles(root string) ProcessorOption {
+	return func(processor *Processor) error {
+		processor.nestedRoot = filepath.Clean(root)
+		return nil
+	}
+}
+
+// resolveNestedRoot validates the root named by WithNestedIgnoreFiles, within the file system of WithFS if any
+func (p *Processor) resolveNestedRoot() error {
+	fileInfo, err := p.stat(p.nestedRoot)
+	if err != nil {
+		return err
+	}
+
+	if !fileInfo.IsDir() {
+		return errors.New("nested ignore file root must be a directory")
+	}
+	return nil
+}
+
 func NewProcessor(opts ...ProcessorOption) (*Processor, error) {
-	// TODO: supporting other strategies would mean inferring strategy from ignore filenames
-	ruleList := make([]rules.Rule, 0)
 	processor := &Processor{
 		strategy: strategies.DefaultStrategy(),
-		ruleList: ruleList,
+		ruleSets: make([]ruleSet, 0),
 	}
 
 	for _, opt := range opts {
@@ -157,5 +880,17 @@ func NewProcessor(opts ...ProcessorOption) (*Processor, error) {
 		}
 	}
 
+	// : paths are resolved once every option is applied, so that WithFS may follow the options naming them
+	if processor.nestedRoot != "" {
+		if err := processor.resolveNestedRoot(); err != nil {
+			return nil, err
+		}
+	}
+	if processor.ignoreFilePath != "" {
+		if err := processor.resolveIgnoreFilePath(); err != nil {
+			return nil, err
+		}
+	}
+
 	return processor, nil
 }
@@ -1,6 +1,7 @@
 package ignore
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/jimschubert/ignore/rules"
 	"github.com/jimschubert/ignore/test"
 )
 
@@ -17,6 +19,22 @@ type AllowTestCondition struct {
 	WantErr bool
 }
 
+// assertConditions reports every condition whose File (using / as the separator) processor doesn't allow as expected
+func assertConditions(t *testing.T, processor *Processor, conditions []AllowTestCondition) {
+	t.Helper()
+	for _, condition := range conditions {
+		isAllowed, e := processor.AllowsFile(filepath.FromSlash(condition.File))
+		if (e != nil) != condition.WantErr {
+			t.Errorf("AllowsFile() condition for path '%s' error = %v, wantErr %v", condition.File, e, condition.WantErr)
+			continue
+		}
+
+		if isAllowed != condition.Allows {
+			t.Errorf("AllowsFile() condition for path '%s' did not allow as expected", condition.File)
+		}
+	}
+}
+
 func TestNewProcessor(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -49,6 +67,50 @@ func TestNewProcessor(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:       "last matching rule wins",
+			ignoreFile: "last_match_wins",
+			conditions: []AllowTestCondition{
+				{File: "debug.log", Allows: false},
+				{File: "keep.log", Allows: false},
+				{File: "important.log", Allows: true},
+				{File: "other.txt", Allows: true},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "excluded parent directory cannot be re-included",
+			ignoreFile: "excluded_parent",
+			conditions: []AllowTestCondition{
+				{File: "build/", Allows: false},
+				{File: "build/keep.txt", Allows: false},
+				{File: "build/nested/keep.txt", Allows: false},
+				{File: "logs/today.txt", Allows: true},
+				{File: "other/a.tmp", Allows: false},
+				{File: "cache/a.tmp", Allows: true},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "single character wildcards and character classes",
+			ignoreFile: "glob_classes",
+			conditions: []AllowTestCondition{
+				{File: "nested/a.b", Allows: false},
+				{File: "nested/abc.d", Allows: true},
+				{File: "module.pyc", Allows: false},
+				{File: "module.pyo", Allows: false},
+				{File: "module.py", Allows: true},
+				{File: "build.log", Allows: false},
+				{File: "Build.log", Allows: false},
+				{File: "rebuild.log", Allows: true},
+				{File: "logs", Allows: false},
+				{File: "log1", Allows: true},
+				{File: "[draft].md", Allows: false},
+				{File: "d.md", Allows: true},
+				{File: "az", Allows: true},
+			},
+			wantErr: false,
+		},
 		{
 			name:       "check simple excludes by directory",
 			ignoreFile: "go_jetbrains_windows",
@@ -70,18 +132,7 @@ func TestNewProcessor(t *testing.T) {
 				return
 			}
 
-			for _, condition := range tt.conditions {
-				isAllowed, e := processor.AllowsFile(condition.File)
-				if (e != nil) != condition.WantErr {
-					t.Errorf("NewProcessor() condition for path '%s' error = %v, wantErr %v  (ignore file %s)", condition.File, e, condition.WantErr, tt.ignoreFile)
-					return
-				}
-
-				if isAllowed != condition.Allows {
-					t.Errorf("NewProcessor() condition for path '%s' did not allow as expected (ignore file %s)", condition.File, tt.ignoreFile)
-					return
-				}
-			}
+			assertConditions(t, processor, tt.conditions)
 		})
 	}
 }
@@ -161,3 +212,749 @@ func ExampleNewProcessor() {
 	// ✓ example/third
 	// ✘ example/third/contents.md
 }
+
+func TestWithNestedIgnoreFiles(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".gitignore":       "*.log\nbuild/\n",
+		"a/.gitignore":     "!keep.log\nlocal.txt\n",
+		"a/b/.gitignore":   "keep.log\n",
+		"build/.gitignore": "!*.txt\n",
+	})
+
+	processor, err := NewProcessor(
+		WithGitignoreStrategy(),
+		WithNestedIgnoreFiles(root),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	conditions := []AllowTestCondition{
+		{File: "debug.log", Allows: false},
+		{File: "a/debug.log", Allows: false},
+		{File: "a/keep.log", Allows: true},
+		{File: "a/b/keep.log", Allows: false},
+		{File: "a/local.txt", Allows: false},
+		{File: "local.txt", Allows: true},
+		{File: "build/output.txt", Allows: false},
+	}
+
+	assertConditions(t, processor, conditions)
+}
+
+func TestProcessor_AllowsPath(t *testing.T) {
+	ignoreContents := test.Data(t, "go_jetbrains_windows")
+	location, cleanup := test.CopyToTempLocation(t, ignoreContents)
+	defer cleanup()
+
+	processor, err := NewProcessor(
+		WithGitignoreStrategy(),
+		WithIgnoreFilePath(location),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	tests := []struct {
+		path   string
+		isDir  bool
+		allows bool
+	}{
+		{path: "out", isDir: true, allows: false},
+		{path: "out", isDir: false, allows: true},
+		{path: "nested/out", isDir: true, allows: false},
+		{path: "out/artifact.bin", isDir: false, allows: false},
+		{path: "cmake-build-debug", isDir: true, allows: false},
+		{path: "prog.dll", isDir: false, allows: false},
+		{path: "src/main.go", isDir: false, allows: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s (dir=%v)", tt.path, tt.isDir), func(t *testing.T) {
+			got, err := processor.AllowsPath(tt.path, tt.isDir)
+			if err != nil {
+				t.Errorf("AllowsPath() error = %v", err)
+				return
+			}
+			if got != tt.allows {
+				t.Errorf("AllowsPath() got = %v, want %v", got, tt.allows)
+			}
+		})
+	}
+}
+
+func TestProcessor_relativePaths(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".gitignore":    "build/\n*.log\n!keep.log\n",
+		"build/out.txt": "",
+		"src/app.log":   "",
+		"src/main.go":   "",
+	})
+
+	processor, err := NewProcessor(
+		WithGitignoreStrategy(),
+		WithIgnoreFilePath(filepath.Join(root, ".gitignore")),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		allows  bool
+		wantErr bool
+	}{
+		{name: "relative to the ignore file", path: filepath.FromSlash("src/app.log"), allows: false},
+		{name: "directory detected within the base", path: "build", allows: false},
+		{name: "dot prefix", path: filepath.FromSlash("./src/app.log"), allows: false},
+		{name: "dot dot within the base", path: filepath.FromSlash("src/../keep.log"), allows: true},
+		{name: "absolute", path: filepath.Join(root, "src", "app.log"), allows: false},
+		{name: "absolute allowed", path: filepath.Join(root, "src", "main.go"), allows: true},
+		{name: "absolute within excluded directory", path: filepath.Join(root, "build", "out.txt"), allows: false},
+		{name: "base directory", path: root, allows: true},
+		{name: "relative outside the base", path: filepath.FromSlash("../other.log"), wantErr: true},
+		{name: "absolute outside the base", path: filepath.Join(filepath.Dir(root), "other.log"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := processor.AllowsFile(tt.path)
+			if tt.wantErr {
+				if !errors.Is(err, ErrOutsideBase) {
+					t.Errorf("AllowsFile(%q) error = %v, want ErrOutsideBase", tt.path, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("AllowsFile(%q) error = %v", tt.path, err)
+				return
+			}
+			if got != tt.allows {
+				t.Errorf("AllowsFile(%q) got = %v, want %v", tt.path, got, tt.allows)
+			}
+		})
+	}
+
+	fsysProcessor, err := NewProcessor(
+		WithGitignoreStrategy(),
+		WithFS(os.DirFS(root)),
+		WithIgnoreFilePath(".gitignore"),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+	if _, err := fsysProcessor.AllowsFile(filepath.Join(root, "src", "app.log")); !errors.Is(err, ErrOutsideBase) {
+		t.Errorf("AllowsFile() of an absolute path within WithFS error = %v, want ErrOutsideBase", err)
+	}
+}
+
+func TestWithDockerignoreStrategy(t *testing.T) {
+	ignoreContents := test.Data(t, "dockerignore")
+	location, cleanup := test.CopyToTempLocation(t, ignoreContents)
+	defer cleanup()
+
+	processor, err := NewProcessor(
+		WithDockerignoreStrategy(),
+		WithIgnoreFilePath(location),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	conditions := []AllowTestCondition{
+		{File: "somedir/temporary.txt", Allows: false},
+		{File: "somedir/subdir/temporary.txt", Allows: false},
+		{File: "temporary.txt", Allows: true},
+		{File: "tempa", Allows: false},
+		{File: "tempab", Allows: true},
+		{File: "a/b/debug.log", Allows: false},
+		{File: "debug.log", Allows: false},
+		{File: "important.log", Allows: true},
+		{File: "node_modules/pkg/index.js", Allows: false},
+		{File: "src/node_modules/pkg/index.js", Allows: true},
+		{File: "vendor/lib/a.go", Allows: false},
+		{File: "vendor/keep/a.go", Allows: true}, // unlike git, exceptions may re-include within excluded directories
+		{File: "CHANGELOG.md", Allows: false},
+		{File: "README.md", Allows: true},
+		{File: "README-secret.md", Allows: false},
+		{File: "docs/guide.md", Allows: true},
+	}
+
+	assertConditions(t, processor, conditions)
+}
+
+func TestWithNpmignoreStrategy(t *testing.T) {
+	tests := []struct {
+		name       string
+		tree       map[string]string
+		conditions []AllowTestCondition
+	}{
+		{
+			name: "reads npmignore in preference to gitignore",
+			tree: map[string]string{
+				"package.json": `{"name": "example", "main": "lib/index.js"}`,
+				".npmignore":   "*.test.js\ndocs/\n",
+				".gitignore":   "lib/\n",
+			},
+			conditions: []AllowTestCondition{
+				{File: "lib/index.js", Allows: true},
+				{File: "lib/index.test.js", Allows: false},
+				{File: "docs/guide.md", Allows: false},
+				{File: "package.json", Allows: true},
+				{File: "README.md", Allows: true},
+				{File: "LICENSE", Allows: true},
+				{File: ".npmrc", Allows: false},
+				{File: ".npmignore", Allows: false},
+				{File: "node_modules/dep/index.js", Allows: false},
+				{File: ".git/config", Allows: false},
+			},
+		},
+		{
+			name: "falls back to gitignore",
+			tree: map[string]string{
+				"package.json": `{"name": "example"}`,
+				".gitignore":   "dist/\n*.log\n",
+			},
+			conditions: []AllowTestCondition{
+				{File: "dist/index.js", Allows: false},
+				{File: "debug.log", Allows: false},
+				{File: "src/index.js", Allows: true},
+				{File: ".gitignore", Allows: false},
+			},
+		},
+		{
+			name: "always includes package files",
+			tree: map[string]string{
+				"package.json": `{"name": "example", "main": "index.js", "bin": {"example": "./cli.js"}}`,
+				".npmignore":   "*.js\n*.md\npackage.json\nLICENSE\n",
+			},
+			conditions: []AllowTestCondition{
+				{File: "index.js", Allows: true},
+				{File: "cli.js", Allows: true},
+				{File: "other.js", Allows: false},
+				{File: "readme.md", Allows: true},
+				{File: "CHANGELOG.md", Allows: false},
+				{File: "package.json", Allows: true},
+				{File: "LICENSE", Allows: true},
+			},
+		},
+		{
+			name: "files list replaces root ignore file",
+			tree: map[string]string{
+				"package.json":     `{"name": "example", "files": ["lib", "bin/*.js"]}`,
+				".npmignore":       "lib/\n",
+				"lib/.npmignore":   "*.map\n",
+				"lib/index.js":     "",
+				"bin/cli.js":       "",
+				"src/index.ts":     "",
+				"CHANGELOG.md":     "",
+				"LICENSE":          "",
+				"lib/index.js.map": "",
+			},
+			conditions: []AllowTestCondition{
+				{File: "lib/index.js", Allows: true},
+				{File: "lib/index.js.map", Allows: false},
+				{File: "bin/cli.js", Allows: true},
+				{File: "bin/cli.sh", Allows: false},
+				{File: "src/index.ts", Allows: false},
+				{File: "CHANGELOG.md", Allows: false},
+				{File: "package.json", Allows: true},
+				{File: "LICENSE", Allows: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := test.Tree(t, tt.tree)
+			processor, err := NewProcessor(
+				WithNpmignoreStrategy(),
+				WithNestedIgnoreFiles(root),
+			)
+			if err != nil {
+				t.Fatalf("NewProcessor() error = %v", err)
+			}
+
+			assertConditions(t, processor, tt.conditions)
+		})
+	}
+}
+
+func TestWithNpmignoreStrategy_missingIgnoreFile(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		"package.json": `{"name": "example"}`,
+	})
+
+	processor, err := NewProcessor(
+		WithNpmignoreStrategy(),
+		WithIgnoreFilePath(filepath.Join(root, ".npmignore")),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	for path, allows := range map[string]bool{"index.js": true, ".npmrc": false} {
+		if got, err := processor.AllowsFile(path); err != nil || got != allows {
+			t.Errorf("AllowsFile(%q) = %v, %v; want %v", path, got, err, allows)
+		}
+	}
+}
+
+func TestWithHgignoreStrategy(t *testing.T) {
+	ignoreContents := test.Data(t, "hgignore")
+	location, cleanup := test.CopyToTempLocation(t, ignoreContents)
+	defer cleanup()
+
+	processor, err := NewProcessor(
+		WithHgignoreStrategy(),
+		WithIgnoreFilePath(location),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	conditions := []AllowTestCondition{
+		{File: "a/b/file.orig", Allows: false},
+		{File: "file.orig.txt", Allows: true},
+		{File: "build/out.bin", Allows: false},
+		{File: "src/build/out.bin", Allows: true},
+		{File: "module.pyc", Allows: false},
+		{File: "pkg/module.pyc", Allows: false},
+		{File: "tmp/debug.log", Allows: false},
+		{File: "a/tmp/debug.log", Allows: false},
+		{File: "a/tmp/nested/debug.log", Allows: true},
+		{File: "docs/_build/index.html", Allows: false},
+		{File: "src/docs/_build/index.html", Allows: false},
+		{File: "dist/app.js", Allows: false},
+		{File: "src/dist/app.js", Allows: true},
+		{File: "setup.cfg", Allows: false},
+		{File: "src/setup.cfg", Allows: true},
+		{File: "secret42.txt", Allows: false},
+		{File: "secret.txt", Allows: true},
+		{File: "vendor/cache/pkg.zip", Allows: false},
+		{File: "vendor/cached", Allows: true},
+	}
+
+	assertConditions(t, processor, conditions)
+}
+
+func TestWithGcloudignoreStrategy(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".gcloudignore":       "#!include:.gitignore\n!debug.log\nnode_modules/\n",
+		".gitignore":          "*.log\n#!include:config/ignore\n",
+		"config/ignore":       "secrets.json\n",
+		"cycle/.gcloudignore": "#!include:other\n",
+		"cycle/other":         "a.txt\n#!include:.gcloudignore\n",
+	})
+
+	processor, err := NewProcessor(
+		WithGcloudignoreStrategy(),
+		WithFS(os.DirFS(root)),
+		WithIgnoreFilePath(".gcloudignore"),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	conditions := []AllowTestCondition{
+		{File: "app.log", Allows: false},
+		{File: "debug.log", Allows: true},
+		{File: "secrets.json", Allows: false},
+		{File: "node_modules/pkg/index.js", Allows: false},
+		{File: "main.go", Allows: true},
+	}
+	assertConditions(t, processor, conditions)
+
+	match, err := processor.Explain("secrets.json")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if match.Source != "config/ignore" || match.Line != 1 {
+		t.Errorf("Explain() provenance = %s:%d, want config/ignore:1", match.Source, match.Line)
+	}
+
+	cyclic, err := NewProcessor(
+		WithGcloudignoreStrategy(),
+		WithFS(os.DirFS(root)),
+		WithIgnoreFilePath("cycle/.gcloudignore"),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		// a failure to read the rules is reported by every call, rather than evaluating without them
+		if _, err := cyclic.AllowsFile("a.txt"); err == nil || !strings.Contains(err.Error(), "include cycle") {
+			t.Errorf("AllowsFile() error = %v, want include cycle", err)
+		}
+	}
+}
+
+func TestWithStignoreStrategy(t *testing.T) {
+	ignoreContents := test.Data(t, "stignore")
+	location, cleanup := test.CopyToTempLocation(t, ignoreContents)
+	defer cleanup()
+
+	processor, err := NewProcessor(
+		WithStignoreStrategy(),
+		WithIgnoreFilePath(location),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	conditions := []AllowTestCondition{
+		{File: "important.tmp", Allows: true}, // the first matching rule wins
+		{File: "a/important.tmp", Allows: true},
+		{File: "other.tmp", Allows: false},
+		{File: "a/Thumbs.DB", Allows: false},
+		{File: "build/output.bin", Allows: false},
+		{File: "KEEP", Allows: true},
+		{File: "keep/nested.txt", Allows: true},
+		{File: "a/keep", Allows: false},
+		{File: "other.txt", Allows: false},
+	}
+	assertConditions(t, processor, conditions)
+
+	flags := map[string]rules.Flags{
+		"a/Thumbs.DB":   rules.CaseInsensitive,
+		"a/.DS_Store":   rules.Deletable,
+		"keep":          rules.CaseInsensitive,
+		"other.tmp":     0,
+		"unmatched.txt": 0,
+	}
+	for path, want := range flags {
+		match, err := processor.Explain(path)
+		if err != nil {
+			t.Fatalf("Explain() error = %v", err)
+		}
+		if match.Rule.Flags() != want {
+			t.Errorf("Explain(%q) flags = %v, want %v", path, match.Rule.Flags(), want)
+		}
+	}
+}
+
+func TestWithStignoreStrategy_include(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".stignore":      "#include ignores/common\n*.log\n",
+		"ignores/common": "!keep.log\n",
+	})
+
+	processor, err := NewProcessor(
+		WithStignoreStrategy(),
+		WithFS(os.DirFS(root)),
+		WithIgnoreFilePath(".stignore"),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	for path, allows := range map[string]bool{"keep.log": true, "a/keep.log": true, "debug.log": false} {
+		if got, err := processor.AllowsFile(path); err != nil || got != allows {
+			t.Errorf("AllowsFile(%q) = %v, %v; want %v", path, got, err, allows)
+		}
+	}
+}
+
+func TestWithRsyncFilterStrategy(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".rsync-filter": "# build outputs only\n" +
+			"- *.tmp\n" +
+			"P /config.ini\n" +
+			": .rsync-filter.local\n" +
+			". rules/common\n" +
+			"+ */\n" +
+			"+ *.c\n" +
+			"- *\n",
+		"rules/common":              "exclude,! /src/***\n",
+		"src/.rsync-filter.local":   "+ keep.txt\n- old.c\n",
+		"src/a/.rsync-filter.local": "- keep.txt\n",
+		"src/main.c":                "",
+		"src/old.c":                 "",
+		"src/keep.txt":              "",
+		"src/a/keep.txt":            "",
+		"src/a/b.c":                 "",
+		"src/a/notes.txt":           "",
+		"src/a/x.tmp":               "",
+		"docs/guide.c":              "",
+		"config.ini":                "",
+	})
+
+	processor, err := NewProcessor(
+		WithRsyncFilterStrategy(),
+		WithFS(os.DirFS(root)),
+		WithIgnoreFilePath(".rsync-filter"),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	conditions := []AllowTestCondition{
+		{File: "src/main.c", Allows: true},
+		{File: "src/a/b.c", Allows: true},
+		{File: "src/a/notes.txt", Allows: false},
+		{File: "src/a/x.tmp", Allows: false},
+		{File: "src/keep.txt", Allows: true},    // from src/.rsync-filter.local
+		{File: "src/a/keep.txt", Allows: false}, // the deeper dir-merge file takes precedence
+		{File: "src/old.c", Allows: false},
+		{File: "docs/guide.c", Allows: false}, // excluded directory is not descended
+		{File: "config.ini", Allows: false},   // protect rules don't affect the transfer
+	}
+	assertConditions(t, processor, conditions)
+
+	match, err := processor.Explain("src/a/keep.txt")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if match.Source != "src/a/.rsync-filter.local" || match.Line != 1 {
+		t.Errorf("Explain() provenance = %s:%d, want src/a/.rsync-filter.local:1", match.Source, match.Line)
+	}
+}
+
+func TestWithRsyncFilterStrategy_clear(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".rsync-filter": "- *.log\n!\n- *.tmp\n",
+	})
+
+	processor, err := NewProcessor(
+		WithRsyncFilterStrategy(),
+		WithFS(os.DirFS(root)),
+		WithIgnoreFilePath(".rsync-filter"),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	for path, allows := range map[string]bool{"debug.log": true, "a.tmp": false} {
+		if got, err := processor.AllowsFile(path); err != nil || got != allows {
+			t.Errorf("AllowsFile(%q) = %v, %v; want %v", path, got, err, allows)
+		}
+	}
+}
+
+func TestWithManifestStrategy(t *testing.T) {
+	ignoreContents := test.Data(t, "manifest")
+	location, cleanup := test.CopyToTempLocation(t, ignoreContents)
+	defer cleanup()
+
+	processor, err := NewProcessor(
+		WithManifestStrategy(),
+		WithIgnoreFilePath(location),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	tests := []struct {
+		path   string
+		isDir  bool
+		allows bool
+	}{
+		{path: "CHANGES.rst", allows: true},
+		{path: "docs/CHANGES.rst", allows: true},
+		{path: "other.rst", allows: false}, // excluded unless selected
+		{path: "docs/index.rst", allows: true},
+		{path: "docs/api/module.txt", allows: true},
+		{path: "docs/conf.py", allows: false},
+		{path: "docs/drafts/next.rst", allows: false},
+		{path: "pkg/py.typed", allows: true},
+		{path: "src/pkg/module.py", allows: true},
+		{path: "src/pkg/module.pyc", allows: false},
+		{path: "src/legacy/old.py", allows: false},
+		{path: "src/secrets.py", allows: false},
+		{path: "setup.py", allows: true},
+		{path: "README.md", allows: true},
+		{path: "LICENSE.txt", allows: true},
+		{path: "build/lib/module.py", allows: false},
+		{path: "src/.git/config", allows: false},
+		{path: "tests", isDir: true, allows: true}, // commands select files, not directories
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := processor.AllowsPath(tt.path, tt.isDir)
+			if err != nil {
+				t.Errorf("AllowsPath() error = %v", err)
+				return
+			}
+			if got != tt.allows {
+				t.Errorf("AllowsPath() got = %v, want %v", got, tt.allows)
+			}
+		})
+	}
+}
+
+func TestWithCvsignoreStrategy(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".cvsignore":       "*.log   build\n",
+		"src/.cvsignore":   "generated.c\n",
+		"third/.cvsignore": "*.o ! *.tmp\n",
+	})
+
+	processor, err := NewProcessor(
+		WithCvsignoreStrategy(),
+		WithNestedIgnoreFiles(root),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	tests := []struct {
+		path   string
+		isDir  bool
+		allows bool
+	}{
+		{path: "debug.log", allows: false},
+		{path: "src/debug.log", allows: true}, // patterns apply only within their own directory
+		{path: "build", isDir: true, allows: false},
+		{path: "build/out.txt", allows: false}, // contents of an ignored directory
+		{path: "src/build/out.txt", allows: true},
+		{path: "src/generated.c", allows: false},
+		{path: "src/main.c", allows: true},
+		{path: "src/main.o", allows: false}, // default ignore list, in every directory
+		{path: "src/main.c~", allows: false},
+		{path: "CVS", isDir: true, allows: false},
+		{path: "third/lib.o", allows: true}, // cleared by !
+		{path: "third/a.tmp", allows: false},
+		{path: "third/nested/lib.o", allows: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := processor.AllowsPath(filepath.FromSlash(tt.path), tt.isDir)
+			if err != nil {
+				t.Errorf("AllowsPath() error = %v", err)
+				return
+			}
+			if got != tt.allows {
+				t.Errorf("AllowsPath() got = %v, want %v", got, tt.allows)
+			}
+		})
+	}
+}
+
+func TestWithSvnignoreStrategy(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".svnignore":     "dist\n*.log\nsvn:global-ignores\n*.cache *.tmp\n",
+		"lib/.svnignore": "svn:ignore\ngenerated\n",
+	})
+
+	processor, err := NewProcessor(
+		WithSvnignoreStrategy(),
+		WithNestedIgnoreFiles(root),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	tests := []struct {
+		path   string
+		isDir  bool
+		allows bool
+	}{
+		{path: "dist", isDir: true, allows: false},
+		{path: "dist/app.js", allows: false},
+		{path: "lib/dist", isDir: true, allows: true}, // svn:ignore isn't recursive
+		{path: "app.log", allows: false},
+		{path: "lib/app.log", allows: true},
+		{path: "a.cache", allows: false},
+		{path: "lib/deep/a.cache", allows: false}, // svn:global-ignores is recursive
+		{path: "lib/deep/a.tmp", allows: false},   // patterns are separated by whitespace
+		{path: "lib/generated", allows: false},
+		{path: "lib/deep/generated", allows: true},
+		{path: "lib/deep/module.pyc", allows: false}, // default global-ignores
+		{path: "lib/Thumbs.db", allows: false},
+		{path: "lib/main.c", allows: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := processor.AllowsPath(filepath.FromSlash(tt.path), tt.isDir)
+			if err != nil {
+				t.Errorf("AllowsPath() error = %v", err)
+				return
+			}
+			if got != tt.allows {
+				t.Errorf("AllowsPath() got = %v, want %v", got, tt.allows)
+			}
+		})
+	}
+}
+
+func TestWithStrategyForFile(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		"docker/.dockerignore": "node_modules\n",
+		"hg/.hgignore":         "syntax: glob\n*.orig\n",
+		"git/.gitignore":       "*.log\n",
+		"other/.ignore":        "*.log\n",
+	})
+
+	tests := []struct {
+		name       string
+		ignoreFile string
+		conditions []AllowTestCondition
+		wantErr    bool
+	}{
+		{
+			name:       "dockerignore",
+			ignoreFile: "docker/.dockerignore",
+			conditions: []AllowTestCondition{
+				{File: "node_modules/pkg/index.js", Allows: false},
+				{File: "src/index.js", Allows: true},
+			},
+		},
+		{
+			name:       "hgignore",
+			ignoreFile: "hg/.hgignore",
+			conditions: []AllowTestCondition{
+				{File: "a/b.orig", Allows: false},
+				{File: "a/b.go", Allows: true},
+			},
+		},
+		{
+			name:       "gitignore",
+			ignoreFile: "git/.gitignore",
+			conditions: []AllowTestCondition{
+				{File: "debug.log", Allows: false},
+			},
+		},
+		{name: "unregistered file name", ignoreFile: "other/.ignore", wantErr: true},
+		{name: "missing file", ignoreFile: "missing/.dockerignore", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := NewProcessor(WithStrategyForFile(filepath.Join(root, filepath.FromSlash(tt.ignoreFile))))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewProcessor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			assertConditions(t, processor, tt.conditions)
+		})
+	}
+}
+
+func TestWithStrategyNamed(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".ignore": "+ *.go\n- *\n",
+	})
+
+	processor, err := NewProcessor(
+		WithStrategyNamed("rsync-filter"),
+		WithIgnoreFilePath(filepath.Join(root, ".ignore")),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	for path, want := range map[string]bool{"main.go": true, "README.md": false} {
+		got, err := processor.AllowsFile(path)
+		if err != nil {
+			t.Errorf("AllowsFile() error = %v", err)
+			continue
+		}
+		if got != want {
+			t.Errorf("AllowsFile(%q) got = %v, want %v", path, got, want)
+		}
+	}
+
+	if _, err := NewProcessor(WithStrategyNamed("unknown")); err == nil {
+		t.Errorf("NewProcessor() expected an error for an unregistered name")
+	}
+}
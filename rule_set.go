@@ -0,0 +1,71 @@
+package ignore
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/jimschubert/ignore/rules"
+)
+
+// ruleSet is the collection of rules read from a single ignore file
+type ruleSet struct {
+	// base is the directory containing the ignore file, relative to the processor's root ("" for the root itself)
+	base string
+	// source is the location from which the rules were read
+	source string
+	rules  []definedRule
+	// fsys is consulted, relative to base, when rules need to know whether a path is a directory
+	fsys fs.FS
+	// everyDirectory applies the rules within every directory, by evaluating only the final element of a path
+	everyDirectory bool
+}
+
+// relativePath converts path to one relative to the rule set's base directory. The boolean result is false when
+// the rule set does not apply to path, which is the case for anything outside base (including base itself).
+func (s ruleSet) relativePath(path string) (string, bool) {
+	if s.everyDirectory {
+		trimmed := strings.TrimSuffix(path, string(filepath.Separator))
+		return path[strings.LastIndex(trimmed, string(filepath.Separator))+1:], true
+	}
+
+	if s.base == "" {
+		return path, true
+	}
+
+	prefix := s.base + string(filepath.Separator)
+	if len(path) <= len(prefix) || !strings.HasPrefix(path, prefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(path, prefix), true
+}
+
+// definedRule is a rule along with the location of its definition within a rule set's source
+type definedRule struct {
+	rules.Rule
+	// line is the 1-based line number of the rule's definition
+	line int
+	// source is the file defining the rule when it differs from the rule set's, as for rules spliced in by an include
+	source string
+	// clear marks a directive discarding the decisions of preceding rules, for the paths to which the rule applies
+	clear bool
+	// dirMerge, when set, marks the position of a per-directory merge directive naming the file to read in each directory
+	dirMerge string
+}
+
+// definedIn provides the location of the rule's definition, given the source of the rule set containing it
+func (r definedRule) definedIn(setSource string) string {
+	if r.source != "" {
+		return r.source
+	}
+	return setSource
+}
+
+// decision is the outcome of evaluating a path against the loaded rule sets
+type decision struct {
+	op rules.Operation
+	// set and rule identify the deciding rule, and are nil when no rule matched
+	set  *ruleSet
+	rule *definedRule
+}
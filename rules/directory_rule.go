@@ -3,7 +3,9 @@ package rules
 import (
 	"bytes"
 	"fmt"
-	"os"
+	"io/fs"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/jimschubert/ignore/parser"
@@ -18,24 +20,58 @@ func (d directoryRule) Evaluate(relativePath string) (Operation, error) {
 	return evaluateRule(d, relativePath)
 }
 
+func (d directoryRule) EvaluateFS(fsys fs.FS, relativePath string) (Operation, error) {
+	return evaluateRuleFS(d, fsys, relativePath)
+}
+
+// Exclude defaults to ExcludeAndTerminate, because nothing beneath an excluded directory can be re-included.
+func (d directoryRule) Exclude() Operation {
+	if d.exclude == nil {
+		return ExcludeAndTerminate
+	}
+	return *d.exclude
+}
+
+func (d directoryRule) EvaluatePath(relativePath string, isDir bool) (Operation, error) {
+	return evaluateRulePath(d, relativePath, isDir)
+}
+
 func (d directoryRule) AppliesTo(relativePath string) bool {
+	return d.AppliesToFS(nil, relativePath)
+}
+
+func (d directoryRule) AppliesToFS(fsys fs.FS, relativePath string) bool {
+	return d.matches(relativePath, kindOf(fsys, relativePath))
+}
+
+func (d directoryRule) AppliesToPath(relativePath string, isDir bool) bool {
+	return d.matches(relativePath, kindFor(isDir))
+}
+
+func (d directoryRule) matches(relativePath string, kind pathKind) bool {
 	// if path exists, but is _not_ a directory, we won't apply a directory rule.
 	// That is, if our rule is /path/to/cupcakes/ and there's a file at /path/to/cupcakes, the rule won't evaluate.
-	if fileInfo, err := os.Stat(relativePath); (err == nil || os.IsExist(err)) && !fileInfo.IsDir() {
+	if kind == fileKind {
 		return false
 	}
 
+	// a path known to be a directory is matched as one, even without a trailing separator
+	if kind == directoryKind && !strings.HasSuffix(relativePath, string(filepath.Separator)) {
+		relativePath += string(filepath.Separator)
+	}
+
+	// negation is handled by evaluateRule, so only the pattern itself is relevant here
 	//nolint:staticcheck
-	noTrail := strings.TrimSuffix(d.rule.Raw(), "/")
-	if strings.Count(noTrail, `/`) == 0 {
-		if singleDirectory, err := filePattern(`(*/)?` + noTrail + `/*`); err == nil {
+	noTrail := strings.TrimSuffix(strings.TrimPrefix(d.rule.Raw(), "!"), "/")
+	if !anchored(d.rule.Raw()) {
+		if singleDirectory, err := regexp.Compile(`^(.*?` + separatorPattern + `)?` + globPattern(noTrail) + separatorPattern + `.*?$`); err == nil {
 			return singleDirectory.MatchString(relativePath)
 		}
 	} else {
 		// This logic taken from .gitignore logic:
 		// For example, a pattern doc/frotz/ matches doc/frotz directory, but not a/doc/frotz directory; however
 		// frotz/ matches frotz and a/frotz that is a directory (all paths are relative from the .gitignore file).
-		if multiDirectory, err := filePattern(`^` + noTrail + `/?*`); err == nil {
+		if multiDirectory, err := regexp.Compile(`^` + globPattern(strings.TrimPrefix(noTrail, "/")) + `(?:` + separatorPattern + `.*)?$`); err == nil {
 			return multiDirectory.MatchString(relativePath)
 		}
 	}
@@ -64,6 +100,8 @@ func NewDirectoryRule(raw string, syntax []parser.TokenValue) (Rule, error) {
 
 // Forces compilation error if interface contract changes (for any reflection use cases)
 var (
-	_ Rule           = &directoryRule{}
-	_ EvaluatingRule = &directoryRule{}
+	_ Rule               = &directoryRule{}
+	_ EvaluatingRule     = &directoryRule{}
+	_ FSEvaluatingRule   = &directoryRule{}
+	_ PathEvaluatingRule = &directoryRule{}
 )
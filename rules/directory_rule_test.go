@@ -3,6 +3,7 @@ package rules
 import (
 	"reflect"
 	"testing"
+	"testing/fstest"
 
 	"github.com/jimschubert/ignore/parser"
 )
@@ -37,13 +38,14 @@ func TestNewDirectoryRule(t *testing.T) {
 		},
 
 		{
-			name: "new with error",
+			name: "new with unknown character class",
 			args: args{
-				raw:    `/path/to/*g(-z]+ng`,
+				raw:    `/path/to/*g[[:nope:]]ng`,
 				syntax: fooSyntax,
 			},
-			want:    &rule{},
-			wantErr: true,
+			want: &directoryRule{
+				rule: rule{raw: `/path/to/*g[[:nope:]]ng`, syntax: fooSyntax},
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -151,7 +153,34 @@ func Test_directoryRule_Evaluate(t *testing.T) {
 		want    Operation
 		wantErr bool
 	}{
-		// TODO: Add test cases.
+		{
+			name: "matching directory excludes and terminates",
+			fields: fields{rule: rule{raw: "foo/", syntax: parts(
+				parser.TokenValue{Token: parser.Text, Value: "foo"},
+				parser.TokenValue{Token: parser.DirectoryMarker},
+			)}},
+			args: args{relativePath: "foo/"},
+			want: ExcludeAndTerminate,
+		},
+		{
+			name: "negated matching directory includes",
+			fields: fields{rule: rule{raw: "!foo/", syntax: parts(
+				parser.TokenValue{Token: parser.Negate},
+				parser.TokenValue{Token: parser.Text, Value: "foo"},
+				parser.TokenValue{Token: parser.DirectoryMarker},
+			)}},
+			args: args{relativePath: "foo/"},
+			want: Include,
+		},
+		{
+			name: "non-matching directory is a noop",
+			fields: fields{rule: rule{raw: "foo/", syntax: parts(
+				parser.TokenValue{Token: parser.Text, Value: "foo"},
+				parser.TokenValue{Token: parser.DirectoryMarker},
+			)}},
+			args: args{relativePath: "bar/"},
+			want: Noop,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -169,3 +198,54 @@ func Test_directoryRule_Evaluate(t *testing.T) {
 		})
 	}
 }
+
+func Test_directoryRule_AppliesToFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"logs/today.txt": &fstest.MapFile{},
+		"cache":          &fstest.MapFile{},
+	}
+	d := directoryRule{rule: rule{raw: "logs/"}}
+	c := directoryRule{rule: rule{raw: "cache/"}}
+
+	tests := []struct {
+		name         string
+		rule         directoryRule
+		relativePath string
+		want         bool
+	}{
+		{name: "applies to directory", rule: d, relativePath: "logs/", want: true},
+		{name: "does not apply to file within directory", rule: d, relativePath: "logs/today.txt", want: false},
+		{name: "applies to missing directory", rule: c, relativePath: "cache/nested/", want: true},
+		{name: "does not apply to file", rule: c, relativePath: "cache/", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.AppliesToFS(fsys, tt.relativePath); got != tt.want {
+				t.Errorf("AppliesToFS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_directoryRule_AppliesToPath(t *testing.T) {
+	d := directoryRule{rule: rule{raw: "build/"}}
+
+	tests := []struct {
+		name         string
+		relativePath string
+		isDir        bool
+		want         bool
+	}{
+		{name: "applies to directory without trailing slash", relativePath: "build", isDir: true, want: true},
+		{name: "applies to nested directory", relativePath: "src/build", isDir: true, want: true},
+		{name: "does not apply to file", relativePath: "build", isDir: false, want: false},
+		{name: "does not apply to other directory", relativePath: "dist", isDir: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.AppliesToPath(tt.relativePath, tt.isDir); got != tt.want {
+				t.Errorf("AppliesToPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+// dockerRule is a rule following Docker's .dockerignore semantics: patterns are anchored at the build context root,
+// and match a path when they match the path itself or any of its parent directories.
+type dockerRule struct {
+	rule
+	pattern *regexp.Regexp
+}
+
+func (d dockerRule) Evaluate(relativePath string) (Operation, error) {
+	return evaluateRule(d, relativePath)
+}
+
+func (d dockerRule) EvaluateFS(fsys fs.FS, relativePath string) (Operation, error) {
+	return evaluateRuleFS(d, fsys, relativePath)
+}
+
+func (d dockerRule) EvaluatePath(relativePath string, isDir bool) (Operation, error) {
+	return evaluateRulePath(d, relativePath, isDir)
+}
+
+// AppliesTo for Docker doesn't distinguish files from directories, so no file system is consulted
+func (d dockerRule) AppliesTo(relativePath string) bool {
+	return d.matches(relativePath)
+}
+
+func (d dockerRule) AppliesToFS(_ fs.FS, relativePath string) bool {
+	return d.matches(relativePath)
+}
+
+func (d dockerRule) AppliesToPath(relativePath string, _ bool) bool {
+	return d.matches(relativePath)
+}
+
+func (d dockerRule) matches(relativePath string) bool {
+	target := strings.TrimSuffix(filepath.ToSlash(relativePath), "/")
+	if d.pattern.MatchString(target) {
+		return true
+	}
+
+	// : a pattern matching a directory also matches everything within it
+	for i := 0; i < len(target); i++ {
+		if target[i] == '/' && d.pattern.MatchString(target[:i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d dockerRule) GoString() string {
+	b := bytes.Buffer{}
+	b.WriteString("dockerRule {")
+	b.WriteString(fmt.Sprintf("\trule:\t%#v", d.rule))
+	b.WriteString(fmt.Sprintf("\tpattern:\t%s", d.pattern.String()))
+	b.WriteString("}")
+	return b.String()
+}
+
+// dockerPattern converts a cleaned, slash-separated .dockerignore pattern to a regular expression, as Docker does
+func dockerPattern(pattern string) (*regexp.Regexp, error) {
+	buf := bytes.Buffer{}
+	buf.WriteString("^")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		current := runes[i]
+		switch {
+		case current == '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				i++
+				// treat **/ as **
+				if i+1 < len(runes) && runes[i+1] == '/' {
+					i++
+				}
+				if i+1 == len(runes) {
+					buf.WriteString(".*")
+				} else {
+					buf.WriteString("(.*/)?")
+				}
+			} else {
+				buf.WriteString("[^/]*")
+			}
+		case current == '?':
+			buf.WriteString("[^/]")
+		case current == '\\':
+			if i+1 < len(runes) {
+				i++
+				buf.WriteString(regexp.QuoteMeta(string(runes[i])))
+			} else {
+				buf.WriteString(`\\`)
+			}
+		case current == '[' || current == ']':
+			buf.WriteRune(current)
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(current)))
+		}
+	}
+
+	buf.WriteString("$")
+	return regexp.Compile(buf.String())
+}
+
+// NewDockerRule constructs a rule following .dockerignore semantics. The pattern is taken from the Text token of
+// syntax, which the dockerignore parser provides already cleaned.
+func NewDockerRule(raw string, syntax []parser.TokenValue) (Rule, error) {
+	pattern := ""
+	for _, part := range syntax {
+		if part.Token == parser.Text {
+			pattern = part.Value
+		}
+	}
+
+	compiled, err := dockerPattern(pattern)
+	if err != nil {
+		return rule{}, err
+	}
+
+	return &dockerRule{
+		rule:    rule{raw: raw, syntax: syntax},
+		pattern: compiled,
+	}, nil
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Rule               = &dockerRule{}
+	_ EvaluatingRule     = &dockerRule{}
+	_ FSEvaluatingRule   = &dockerRule{}
+	_ PathEvaluatingRule = &dockerRule{}
+)
@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+func Test_dockerRule_AppliesTo(t *testing.T) {
+	tests := []struct {
+		name         string
+		pattern      string
+		relativePath string
+		want         bool
+	}{
+		{name: "exact file", pattern: "Dockerfile", relativePath: "Dockerfile", want: true},
+		{name: "anchored at root", pattern: "Dockerfile", relativePath: "sub/Dockerfile", want: false},
+		{name: "directory contents", pattern: "node_modules", relativePath: "node_modules/a/b.js", want: true},
+		{name: "directory with trailing slash", pattern: "node_modules", relativePath: "node_modules/", want: true},
+		{name: "star does not cross directories", pattern: "*.md", relativePath: "docs/README.md", want: false},
+		{name: "star within directory", pattern: "*/*.md", relativePath: "docs/README.md", want: true},
+		{name: "double star any depth", pattern: "**/*.go", relativePath: "a/b/c.go", want: true},
+		{name: "double star zero depth", pattern: "**/*.go", relativePath: "c.go", want: true},
+		{name: "trailing double star", pattern: "build/**", relativePath: "build/x/y", want: true},
+		{name: "question mark", pattern: "file?.txt", relativePath: "file1.txt", want: true},
+		{name: "question mark not separator", pattern: "a?b", relativePath: "a/b", want: false},
+		{name: "character class", pattern: "file[0-9].txt", relativePath: "file7.txt", want: true},
+		{name: "escaped character", pattern: `\*.txt`, relativePath: "*.txt", want: true},
+		{name: "literal dot", pattern: "a.txt", relativePath: "abtxt", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewDockerRule(tt.pattern, parts(parser.TokenValue{Token: parser.Text, Value: tt.pattern}))
+			if err != nil {
+				t.Fatalf("NewDockerRule() error = %v", err)
+			}
+			if got := r.(EvaluatingRule).AppliesTo(tt.relativePath); got != tt.want {
+				t.Errorf("AppliesTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dockerRule_Evaluate(t *testing.T) {
+	negated, _ := NewDockerRule("!build/keep", parts(
+		parser.TokenValue{Token: parser.Negate},
+		parser.TokenValue{Token: parser.Text, Value: "build/keep"},
+	))
+	excluded, _ := NewDockerRule("build", parts(parser.TokenValue{Token: parser.Text, Value: "build"}))
+
+	tests := []struct {
+		name         string
+		rule         Rule
+		relativePath string
+		want         Operation
+	}{
+		{name: "excluded directory does not terminate", rule: excluded, relativePath: "build/", want: Exclude},
+		{name: "exception includes", rule: negated, relativePath: "build/keep", want: Include},
+		{name: "no match", rule: excluded, relativePath: "src", want: Noop},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.(EvaluatingRule).Evaluate(tt.relativePath)
+			if err != nil {
+				t.Errorf("Evaluate() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Evaluate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,7 @@ package rules
 import (
 	"bytes"
 	"fmt"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -11,7 +11,8 @@ import (
 	"github.com/jimschubert/ignore/parser"
 )
 
-// fileRule is a rule which applies to files, given a defined extension and target filename pattern
+// fileRule is a rule which applies to files, given a defined extension and target filename pattern. As with git, a
+// pattern without a trailing separator also matches directories, excluding everything within them.
 type fileRule struct {
 	rule
 	definedExt      string
@@ -19,19 +20,51 @@ type fileRule struct {
 }
 
 func (f fileRule) Evaluate(relativePath string) (Operation, error) {
-	return evaluateRule(f, relativePath)
+	return f.EvaluateFS(nil, relativePath)
 }
 
+func (f fileRule) EvaluateFS(fsys fs.FS, relativePath string) (Operation, error) {
+	return f.operation(relativePath, kindOf(fsys, relativePath)), nil
+}
+
+func (f fileRule) EvaluatePath(relativePath string, isDir bool) (Operation, error) {
+	return f.operation(relativePath, kindFor(isDir)), nil
+}
+
+// AppliesTo for a file rule doesn't distinguish files from directories, so no file system is consulted
 func (f fileRule) AppliesTo(relativePath string) bool {
-	// Directories aren't files and should be evaluated only via directoryRule
-	if fileInfo, err := os.Stat(relativePath); (err == nil || os.IsExist(err)) && fileInfo.IsDir() {
-		return false
+	return f.matches(relativePath)
+}
+
+func (f fileRule) AppliesToFS(_ fs.FS, relativePath string) bool {
+	return f.matches(relativePath)
+}
+
+func (f fileRule) AppliesToPath(relativePath string, _ bool) bool {
+	return f.matches(relativePath)
+}
+
+// operation determines Rule.Include vs Rule.Exclude for relativePath. Excluding a directory terminates, because nothing
+// beneath an excluded directory can be re-included.
+func (f fileRule) operation(relativePath string, kind pathKind) Operation {
+	op := operationFor(f, f.matches(relativePath))
+	if op == Exclude && (kind == directoryKind || strings.HasSuffix(relativePath, string(filepath.Separator))) {
+		return ExcludeAndTerminate
 	}
+	return op
+}
+
+func (f fileRule) matches(relativePath string) bool {
+	relativePath = strings.TrimSuffix(relativePath, string(filepath.Separator))
+
 	// todo: consider filepath.Match
-	evaluatedExt := strings.TrimPrefix(filepath.Ext(relativePath), ".")
-	if extensionPattern, err := filePattern(strings.TrimPrefix(f.definedExt, ".")); err == nil {
-		if !extensionPattern.MatchString(evaluatedExt) {
-			return false
+	// a pattern without an extension (e.g. README*) may still match a file having one
+	if f.definedExt != "" {
+		evaluatedExt := strings.TrimPrefix(filepath.Ext(relativePath), ".")
+		if extensionPattern, err := filePattern(strings.TrimPrefix(f.definedExt, ".")); err == nil {
+			if !extensionPattern.MatchString(evaluatedExt) {
+				return false
+			}
 		}
 	}
 
@@ -49,8 +82,13 @@ func (f fileRule) GoString() string {
 }
 
 func NewFileRule(raw string, syntax []parser.TokenValue) (Rule, error) {
-	definedExt := filepath.Ext(raw)
-	pattern, err := filePattern(raw)
+	definedExt := literalExtension(raw)
+	newPattern := filePattern
+	if !anchored(raw) {
+		newPattern = unanchoredFilePattern
+	}
+
+	pattern, err := newPattern(raw)
 	if err != nil {
 		return rule{}, err
 	}
@@ -64,6 +102,8 @@ func NewFileRule(raw string, syntax []parser.TokenValue) (Rule, error) {
 
 // Forces compilation error if interface contract changes (for any reflection use cases)
 var (
-	_ Rule           = &fileRule{}
-	_ EvaluatingRule = &fileRule{}
+	_ Rule               = &fileRule{}
+	_ EvaluatingRule     = &fileRule{}
+	_ FSEvaluatingRule   = &fileRule{}
+	_ PathEvaluatingRule = &fileRule{}
 )
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"testing/fstest"
 
 	"github.com/jimschubert/ignore/parser"
 )
@@ -39,13 +40,15 @@ func TestNewFileRule(t *testing.T) {
 		},
 
 		{
-			name: "new with error",
+			name: "new with unknown character class",
 			args: args{
-				raw:    `/path/to/*g(-z]+ng`,
+				raw:    `/path/to/*g[[:nope:]]ng`,
 				syntax: fooSyntax,
 			},
-			want:    &rule{},
-			wantErr: true,
+			want: &fileRule{
+				rule:            rule{raw: `/path/to/*g[[:nope:]]ng`, syntax: fooSyntax},
+				filenamePattern: regexp.MustCompile(`^path/to/[^/]*g` + neverMatchesPattern + `ng$`),
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -113,6 +116,21 @@ func Test_fileRule_AppliesTo(t *testing.T) {
 			args: args{relativePath: "bar"},
 			want: false,
 		},
+		{
+			name: "match: no extension to valid target with extension",
+			fields: fields{
+				rule: rule{
+					raw: "README*",
+					syntax: parts(
+						parser.TokenValue{Token: parser.Text, Value: "README"},
+						parser.TokenValue{Token: parser.MatchAny},
+					),
+				},
+				filenamePattern: regexp.MustCompile(`^README.*?$`),
+			},
+			args: args{relativePath: "README.md"},
+			want: true,
+		},
 		// endregion no extension
 
 		// region with extension
@@ -302,3 +320,70 @@ func Test_fileRule_Evaluate(t *testing.T) {
 		})
 	}
 }
+
+func Test_fileRule_AppliesToFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"notes.txt/a.md": &fstest.MapFile{},
+		"todo.txt":       &fstest.MapFile{},
+	}
+	f := fileRule{
+		rule:            rule{raw: "*.txt"},
+		definedExt:      ".txt",
+		filenamePattern: regexp.MustCompile(`^.*?\.txt$`),
+	}
+
+	tests := []struct {
+		name         string
+		relativePath string
+		want         bool
+	}{
+		{name: "applies to file", relativePath: "todo.txt", want: true},
+		{name: "applies to missing file", relativePath: "missing.txt", want: true},
+		{name: "applies to directory", relativePath: "notes.txt", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.AppliesToFS(fsys, tt.relativePath); got != tt.want {
+				t.Errorf("AppliesToFS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fileRule_EvaluatePath(t *testing.T) {
+	f := fileRule{
+		rule:            rule{raw: "*.txt"},
+		definedExt:      ".txt",
+		filenamePattern: regexp.MustCompile(`^.*?\.txt$`),
+	}
+	negated := fileRule{
+		rule:            rule{raw: "!*.txt", syntax: parts(parser.TokenValue{Token: parser.Negate})},
+		definedExt:      ".txt",
+		filenamePattern: regexp.MustCompile(`^.*?\.txt$`),
+	}
+
+	tests := []struct {
+		name         string
+		rule         fileRule
+		relativePath string
+		isDir        bool
+		want         Operation
+	}{
+		{name: "excludes file", rule: f, relativePath: "todo.txt", isDir: false, want: Exclude},
+		{name: "includes negated file", rule: negated, relativePath: "todo.txt", isDir: false, want: Include},
+		{name: "excludes directory", rule: f, relativePath: "notes.txt", isDir: true, want: ExcludeAndTerminate},
+		{name: "includes negated directory", rule: negated, relativePath: "notes.txt", isDir: true, want: Include},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.EvaluatePath(tt.relativePath, tt.isDir)
+			if err != nil {
+				t.Errorf("EvaluatePath() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("EvaluatePath() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package rules
+
+import "strings"
+
+// Flags are modifiers declared by a rule, beyond whether it is negated
+type Flags uint8
+
+const (
+	// CaseInsensitive rules match paths regardless of case
+	CaseInsensitive Flags = 1 << iota
+	// Deletable rules exclude files which may be deleted when they prevent the removal of a directory
+	Deletable
+)
+
+// Has determines whether all of flag are set
+func (f Flags) Has(flag Flags) bool {
+	return f&flag == flag
+}
+
+// String representation of Flags, as a space-separated list of names
+func (f Flags) String() string {
+	names := make([]string, 0)
+	if f.Has(CaseInsensitive) {
+		names = append(names, "CaseInsensitive")
+	}
+	if f.Has(Deletable) {
+		names = append(names, "Deletable")
+	}
+	return strings.Join(names, " ")
+}
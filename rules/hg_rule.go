@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+// hgSuffix completes glob and path patterns, so that they match a directory as well as everything within it
+const hgSuffix = `(?:/|$)`
+
+// hgRule is a rule following Mercurial's .hgignore semantics, where each pattern is converted to a regular
+// expression according to its syntax. Mercurial doesn't descend into an ignored directory, so a rule matching any
+// parent directory of a path also matches the path.
+type hgRule struct {
+	rule
+	syntax  string
+	pattern *regexp.Regexp
+}
+
+func (h hgRule) Evaluate(relativePath string) (Operation, error) {
+	return evaluateRule(h, relativePath)
+}
+
+func (h hgRule) EvaluateFS(fsys fs.FS, relativePath string) (Operation, error) {
+	return evaluateRuleFS(h, fsys, relativePath)
+}
+
+func (h hgRule) EvaluatePath(relativePath string, isDir bool) (Operation, error) {
+	return evaluateRulePath(h, relativePath, isDir)
+}
+
+// AppliesTo for Mercurial doesn't distinguish files from directories, so no file system is consulted
+func (h hgRule) AppliesTo(relativePath string) bool {
+	return h.matches(relativePath)
+}
+
+func (h hgRule) AppliesToFS(_ fs.FS, relativePath string) bool {
+	return h.matches(relativePath)
+}
+
+func (h hgRule) AppliesToPath(relativePath string, _ bool) bool {
+	return h.matches(relativePath)
+}
+
+func (h hgRule) matches(relativePath string) bool {
+	target := strings.TrimSuffix(filepath.ToSlash(relativePath), "/")
+	if h.pattern.MatchString(target) {
+		return true
+	}
+
+	for i := 0; i < len(target); i++ {
+		if target[i] == '/' && h.pattern.MatchString(target[:i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h hgRule) GoString() string {
+	b := bytes.Buffer{}
+	b.WriteString("hgRule {")
+	b.WriteString(fmt.Sprintf("\trule:\t%#v", h.rule))
+	b.WriteString(fmt.Sprintf("\tsyntax:\t%s", h.syntax))
+	b.WriteString(fmt.Sprintf("\tpattern:\t%s", h.pattern.String()))
+	b.WriteString("}")
+	return b.String()
+}
+
+// hgPattern converts a pattern of the given Mercurial syntax to a regular expression, as Mercurial does
+func hgPattern(syntax string, pattern string) (*regexp.Regexp, error) {
+	switch syntax {
+	case parser.HgRegexp:
+		// : an unrooted regexp matches anywhere within a path
+		return regexp.Compile(pattern)
+	case parser.HgGlob:
+		return regexp.Compile(`^(?:|.*/)` + hgGlob(pattern) + hgSuffix)
+	case parser.HgRootGlob:
+		return regexp.Compile(`^` + hgGlob(pattern) + hgSuffix)
+	case parser.HgPath:
+		if pattern == "." {
+			return regexp.Compile(`^`)
+		}
+		return regexp.Compile(`^` + regexp.QuoteMeta(strings.Trim(pattern, "/")) + hgSuffix)
+	default:
+		return nil, fmt.Errorf("unsupported pattern syntax '%s'", syntax)
+	}
+}
+
+// hgGlob converts a Mercurial glob to (unanchored) regular expression syntax
+func hgGlob(pattern string) string {
+	buf := bytes.Buffer{}
+	group := 0
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		current := runes[i]
+		switch {
+		case current == '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				i++
+				if i+1 < len(runes) && runes[i+1] == '/' {
+					i++
+					buf.WriteString("(?:.*/)?")
+				} else {
+					buf.WriteString(".*")
+				}
+			} else {
+				buf.WriteString("[^/]*")
+			}
+		case current == '?':
+			buf.WriteString(".")
+		case current == '[':
+			class, end := bracketExpression(runes, i, bracketSyntax{fnmatch: true})
+			if end < 0 {
+				// : an unclosed bracket is literal
+				buf.WriteString(`\[`)
+				continue
+			}
+			buf.WriteString(class)
+			i = end
+		case current == '{':
+			group++
+			buf.WriteString("(?:")
+		case current == '}' && group > 0:
+			group--
+			buf.WriteString(")")
+		case current == ',' && group > 0:
+			buf.WriteString("|")
+		case current == '\\' && i+1 < len(runes):
+			i++
+			buf.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(current)))
+		}
+	}
+
+	return buf.String()
+}
+
+// NewHgRule constructs a rule following .hgignore semantics, from the Syntax and Text tokens provided by the
+// hgignore parser.
+func NewHgRule(raw string, syntax []parser.TokenValue) (Rule, error) {
+	kind := parser.HgRegexp
+	pattern := ""
+	for _, part := range syntax {
+		switch part.Token {
+		case parser.Syntax:
+			kind = part.Value
+		case parser.Text:
+			pattern = part.Value
+		}
+	}
+
+	compiled, err := hgPattern(kind, pattern)
+	if err != nil {
+		return rule{}, err
+	}
+
+	return &hgRule{
+		rule:    rule{raw: raw, syntax: syntax},
+		syntax:  kind,
+		pattern: compiled,
+	}, nil
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Rule               = &hgRule{}
+	_ EvaluatingRule     = &hgRule{}
+	_ FSEvaluatingRule   = &hgRule{}
+	_ PathEvaluatingRule = &hgRule{}
+)
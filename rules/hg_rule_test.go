@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+func Test_hgRule_AppliesTo(t *testing.T) {
+	tests := []struct {
+		name         string
+		syntax       string
+		pattern      string
+		relativePath string
+		want         bool
+	}{
+		{name: "regexp matches anywhere", syntax: parser.HgRegexp, pattern: `\.orig$`, relativePath: "a/b.orig", want: true},
+		{name: "regexp anchored", syntax: parser.HgRegexp, pattern: `^build/`, relativePath: "src/build/a", want: false},
+		{name: "regexp parent directory", syntax: parser.HgRegexp, pattern: `^build$`, relativePath: "build/a", want: true},
+		{name: "glob any level", syntax: parser.HgGlob, pattern: "*.pyc", relativePath: "a/b/c.pyc", want: true},
+		{name: "glob star within directory", syntax: parser.HgGlob, pattern: "a/*.c", relativePath: "a/b/c.c", want: false},
+		{name: "glob directory contents", syntax: parser.HgGlob, pattern: "node_modules", relativePath: "x/node_modules/y.js", want: true},
+		{name: "glob partial name", syntax: parser.HgGlob, pattern: "node", relativePath: "node_modules", want: false},
+		{name: "glob double star", syntax: parser.HgGlob, pattern: "a/**/c", relativePath: "a/c", want: true},
+		{name: "glob character class", syntax: parser.HgGlob, pattern: "file[!0-9]", relativePath: "filex", want: true},
+		{name: "glob negated character class", syntax: parser.HgGlob, pattern: "file[!0-9]", relativePath: "file1", want: false},
+		{name: "glob leading bracket in character class", syntax: parser.HgGlob, pattern: "[]a]", relativePath: "]", want: true},
+		{name: "glob backslash in character class", syntax: parser.HgGlob, pattern: `a[\b]`, relativePath: `a\`, want: true},
+		{name: "glob braces", syntax: parser.HgGlob, pattern: "*.{c,h}", relativePath: "x.h", want: true},
+		{name: "glob unclosed bracket", syntax: parser.HgGlob, pattern: "a[b", relativePath: "a[b", want: true},
+		{name: "rootglob anchored", syntax: parser.HgRootGlob, pattern: "*.cfg", relativePath: "a/setup.cfg", want: false},
+		{name: "rootglob at root", syntax: parser.HgRootGlob, pattern: "*.cfg", relativePath: "setup.cfg", want: true},
+		{name: "path literal", syntax: parser.HgPath, pattern: "a.b", relativePath: "axb", want: false},
+		{name: "path directory", syntax: parser.HgPath, pattern: "vendor", relativePath: "vendor/x", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewHgRule(tt.pattern, parts(
+				parser.TokenValue{Token: parser.Syntax, Value: tt.syntax},
+				parser.TokenValue{Token: parser.Text, Value: tt.pattern},
+			))
+			if err != nil {
+				t.Fatalf("NewHgRule() error = %v", err)
+			}
+			if got := r.(EvaluatingRule).AppliesTo(tt.relativePath); got != tt.want {
+				t.Errorf("AppliesTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewHgRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		syntax  string
+		pattern string
+		wantErr bool
+	}{
+		{name: "valid regexp", syntax: parser.HgRegexp, pattern: `^a+$`},
+		{name: "invalid regexp", syntax: parser.HgRegexp, pattern: `a(`, wantErr: true},
+		{name: "unknown syntax", syntax: "fancy", pattern: "a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHgRule(tt.pattern, parts(
+				parser.TokenValue{Token: parser.Syntax, Value: tt.syntax},
+				parser.TokenValue{Token: parser.Text, Value: tt.pattern},
+			))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewHgRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+// manifestRule is a command of Python's MANIFEST.in, which adds files to (or removes files from) an sdist. Commands
+// select files, so a manifestRule never applies to a directory.
+type manifestRule struct {
+	rule
+	command string
+	pattern *regexp.Regexp
+}
+
+func (m manifestRule) Evaluate(relativePath string) (Operation, error) {
+	return evaluateRule(m, relativePath)
+}
+
+func (m manifestRule) EvaluateFS(fsys fs.FS, relativePath string) (Operation, error) {
+	return evaluateRuleFS(m, fsys, relativePath)
+}
+
+func (m manifestRule) EvaluatePath(relativePath string, isDir bool) (Operation, error) {
+	return evaluateRulePath(m, relativePath, isDir)
+}
+
+// Exclude is the operation of a matching command: commands which add files include, and the others exclude
+func (m manifestRule) Exclude() Operation {
+	switch m.command {
+	case parser.ManifestInclude, parser.ManifestRecursiveInclude, parser.ManifestGlobalInclude, parser.ManifestGraft:
+		return Include
+	default:
+		return Exclude
+	}
+}
+
+func (m manifestRule) AppliesTo(relativePath string) bool {
+	return m.AppliesToFS(nil, relativePath)
+}
+
+func (m manifestRule) AppliesToFS(fsys fs.FS, relativePath string) bool {
+	return m.matches(relativePath, kindOf(fsys, relativePath))
+}
+
+func (m manifestRule) AppliesToPath(relativePath string, isDir bool) bool {
+	return m.matches(relativePath, kindFor(isDir))
+}
+
+func (m manifestRule) matches(relativePath string, kind pathKind) bool {
+	if kind == directoryKind || strings.HasSuffix(relativePath, "/") || strings.HasSuffix(relativePath, string(filepath.Separator)) {
+		return false
+	}
+
+	return m.pattern.MatchString(filepath.ToSlash(relativePath))
+}
+
+func (m manifestRule) GoString() string {
+	b := bytes.Buffer{}
+	b.WriteString("manifestRule {")
+	b.WriteString(fmt.Sprintf("\trule:\t%#v", m.rule))
+	b.WriteString(fmt.Sprintf("\tcommand:\t%s", m.command))
+	b.WriteString(fmt.Sprintf("\tpattern:\t%s", m.pattern.String()))
+	b.WriteString("}")
+	return b.String()
+}
+
+// manifestGlob converts a MANIFEST.in pattern to (unanchored) regular expression syntax, as setuptools does
+func manifestGlob(glob string) string {
+	buf := bytes.Buffer{}
+
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		current := runes[i]
+		switch {
+		case current == '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				i++
+				buf.WriteString(".*")
+			} else {
+				buf.WriteString("[^/]*")
+			}
+		case current == '?':
+			buf.WriteString("[^/]")
+		case current == '[':
+			class, end := bracketExpression(runes, i, bracketSyntax{fnmatch: true})
+			if end < 0 {
+				// : an unterminated bracket expression is matched literally
+				buf.WriteString(`\[`)
+				continue
+			}
+			buf.WriteString(class)
+			i = end
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(current)))
+		}
+	}
+
+	return buf.String()
+}
+
+// manifestDirectory converts a directory argument to a regular expression matching the path prefix of its contents
+func manifestDirectory(dir string) string {
+	dir = strings.Trim(filepath.ToSlash(filepath.Clean(dir)), "/")
+	if dir == "." || dir == "" {
+		return ""
+	}
+	return manifestGlob(dir) + "/"
+}
+
+// manifestPattern converts the arguments of a command to a regular expression matching the files it selects
+func manifestPattern(command string, arguments []string) (*regexp.Regexp, error) {
+	alternatives := make([]string, 0, len(arguments))
+	switch command {
+	case parser.ManifestRecursiveInclude, parser.ManifestRecursiveExclude:
+		// : files beneath the directory, at any depth, matching any of the patterns
+		prefix := manifestDirectory(arguments[0])
+		for _, pattern := range arguments[1:] {
+			alternatives = append(alternatives, prefix+"(?:.*/)?"+manifestGlob(pattern))
+		}
+	case parser.ManifestGlobalInclude, parser.ManifestGlobalExclude:
+		// : files anywhere in the tree whose name matches any of the patterns
+		for _, pattern := range arguments {
+			alternatives = append(alternatives, "(?:.*/)?"+manifestGlob(pattern))
+		}
+	case parser.ManifestGraft, parser.ManifestPrune:
+		alternatives = append(alternatives, manifestDirectory(arguments[0])+".*")
+	default:
+		// : include and exclude patterns are relative to the project root
+		for _, pattern := range arguments {
+			alternatives = append(alternatives, manifestGlob(strings.TrimPrefix(pattern, "./")))
+		}
+	}
+
+	return regexp.Compile(`^(?:` + strings.Join(alternatives, "|") + `)$`)
+}
+
+// NewManifestRule constructs a rule for a MANIFEST.in command, from the Command and Text tokens of the manifest parser
+func NewManifestRule(raw string, syntax []parser.TokenValue) (Rule, error) {
+	command := ""
+	arguments := make([]string, 0)
+	for _, part := range syntax {
+		switch part.Token {
+		case parser.Command:
+			command = part.Value
+		case parser.Text:
+			arguments = append(arguments, part.Value)
+		}
+	}
+
+	if len(arguments) == 0 {
+		return rule{}, fmt.Errorf("command '%s' has no arguments", command)
+	}
+
+	compiled, err := manifestPattern(command, arguments)
+	if err != nil {
+		return rule{}, err
+	}
+
+	return &manifestRule{
+		rule:    rule{raw: raw, syntax: syntax},
+		command: command,
+		pattern: compiled,
+	}, nil
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Rule               = &manifestRule{}
+	_ EvaluatingRule     = &manifestRule{}
+	_ FSEvaluatingRule   = &manifestRule{}
+	_ PathEvaluatingRule = &manifestRule{}
+)
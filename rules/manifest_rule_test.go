@@ -0,0 +1,54 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+func Test_manifestRule_EvaluatePath(t *testing.T) {
+	tests := []struct {
+		name         string
+		line         string
+		relativePath string
+		isDir        bool
+		want         Operation
+	}{
+		{name: "include anchored", line: "include *.txt", relativePath: "a.txt", want: Include},
+		{name: "include does not recurse", line: "include *.txt", relativePath: "docs/a.txt", want: Noop},
+		{name: "include any pattern", line: "include a.txt b.txt", relativePath: "b.txt", want: Include},
+		{name: "exclude", line: "exclude a.txt", relativePath: "a.txt", want: Exclude},
+		{name: "recursive include", line: "recursive-include docs *.rst", relativePath: "docs/a/b.rst", want: Include},
+		{name: "recursive include outside directory", line: "recursive-include docs *.rst", relativePath: "src/b.rst", want: Noop},
+		{name: "recursive exclude", line: "recursive-exclude . *.orig", relativePath: "a/b.orig", want: Exclude},
+		{name: "global include", line: "global-include *.typed", relativePath: "a/b/py.typed", want: Include},
+		{name: "global exclude character class", line: "global-exclude *.py[co]", relativePath: "a/b.pyc", want: Exclude},
+		{name: "leading bracket in character class", line: "include []a]", relativePath: "]", want: Include},
+		{name: "backslash in character class", line: `include a[\b]`, relativePath: `a\`, want: Include},
+		{name: "caret in character class", line: "include [^a]", relativePath: "^", want: Include},
+		{name: "graft", line: "graft src", relativePath: "src/a/b.py", want: Include},
+		{name: "graft similar name", line: "graft src", relativePath: "srcs/b.py", want: Noop},
+		{name: "prune", line: "prune build/", relativePath: "build/x", want: Exclude},
+		{name: "never a directory", line: "graft src", relativePath: "src/a", isDir: true, want: Noop},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syntax, err := parser.NewManifestParser().ParseLine(tt.line)
+			if err != nil {
+				t.Fatalf("ParseLine() error = %v", err)
+			}
+			r, err := NewManifestRule(tt.line, syntax)
+			if err != nil {
+				t.Fatalf("NewManifestRule() error = %v", err)
+			}
+
+			got, err := r.(PathEvaluatingRule).EvaluatePath(tt.relativePath, tt.isDir)
+			if err != nil {
+				t.Fatalf("EvaluatePath() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EvaluatePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
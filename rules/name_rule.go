@@ -0,0 +1,146 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+// nameRule is a rule matching the name of an entry within the directory defining it, as with CVS and Subversion.
+// Unless recursive, it doesn't apply to anything deeper. Neither tool descends into an ignored directory.
+type nameRule struct {
+	rule
+	recursive bool
+	pattern   *regexp.Regexp
+}
+
+func (n nameRule) Evaluate(relativePath string) (Operation, error) {
+	return evaluateRule(n, relativePath)
+}
+
+func (n nameRule) EvaluateFS(fsys fs.FS, relativePath string) (Operation, error) {
+	return evaluateRuleFS(n, fsys, relativePath)
+}
+
+func (n nameRule) EvaluatePath(relativePath string, isDir bool) (Operation, error) {
+	return evaluateRulePath(n, relativePath, isDir)
+}
+
+// Exclude defaults to ExcludeAndTerminate, because the contents of an ignored directory are never examined
+func (n nameRule) Exclude() Operation {
+	if n.exclude == nil {
+		return ExcludeAndTerminate
+	}
+	return *n.exclude
+}
+
+// AppliesTo for names doesn't distinguish files from directories, so no file system is consulted
+func (n nameRule) AppliesTo(relativePath string) bool {
+	return n.matches(relativePath)
+}
+
+func (n nameRule) AppliesToFS(_ fs.FS, relativePath string) bool {
+	return n.matches(relativePath)
+}
+
+func (n nameRule) AppliesToPath(relativePath string, _ bool) bool {
+	return n.matches(relativePath)
+}
+
+func (n nameRule) matches(relativePath string) bool {
+	target := strings.TrimSuffix(filepath.ToSlash(relativePath), "/")
+	name := target[strings.LastIndex(target, "/")+1:]
+	if !n.recursive && name != target {
+		return false
+	}
+
+	return n.pattern.MatchString(name)
+}
+
+func (n nameRule) GoString() string {
+	b := bytes.Buffer{}
+	b.WriteString("nameRule {")
+	b.WriteString(fmt.Sprintf("\trule:\t%#v", n.rule))
+	b.WriteString(fmt.Sprintf("\trecursive:\t%v", n.recursive))
+	b.WriteString(fmt.Sprintf("\tpattern:\t%s", n.pattern.String()))
+	b.WriteString("}")
+	return b.String()
+}
+
+// fnmatchPattern converts a shell wildcard pattern, as matched by fnmatch(3) without flags, to regular expression
+// syntax
+func fnmatchPattern(pattern string) string {
+	buf := bytes.Buffer{}
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		current := runes[i]
+		switch {
+		case current == '*':
+			buf.WriteString(".*")
+		case current == '?':
+			buf.WriteString(".")
+		case current == '[':
+			// without FNM_PATHNAME, a bracket expression may match any character
+			class, end := bracketExpression(runes, i, bracketSyntax{})
+			if end < 0 {
+				buf.WriteString(`\[`)
+				continue
+			}
+			buf.WriteString(class)
+			i = end
+		case current == '\\' && i+1 < len(runes):
+			i++
+			buf.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(current)))
+		}
+	}
+
+	return buf.String()
+}
+
+// NewNameRule constructs a rule matching entry names against any of the patterns of syntax's Text tokens. A Clear
+// token (without patterns) matches every entry, and a Recursive token applies the rule at any depth.
+func NewNameRule(raw string, syntax []parser.TokenValue) (Rule, error) {
+	recursive := false
+	alternatives := make([]string, 0)
+	for _, part := range syntax {
+		switch part.Token {
+		case parser.Recursive:
+			recursive = true
+		case parser.Text:
+			alternatives = append(alternatives, fnmatchPattern(part.Value))
+		case parser.Clear:
+			alternatives = append(alternatives, ".*")
+		}
+	}
+
+	if len(alternatives) == 0 {
+		return rule{}, fmt.Errorf("rule '%s' has no patterns", raw)
+	}
+
+	compiled, err := regexp.Compile(`^(?:` + strings.Join(alternatives, "|") + `)$`)
+	if err != nil {
+		return rule{}, err
+	}
+
+	return &nameRule{
+		rule:      rule{raw: raw, syntax: syntax},
+		recursive: recursive,
+		pattern:   compiled,
+	}, nil
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Rule               = &nameRule{}
+	_ EvaluatingRule     = &nameRule{}
+	_ FSEvaluatingRule   = &nameRule{}
+	_ PathEvaluatingRule = &nameRule{}
+)
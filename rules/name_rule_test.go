@@ -0,0 +1,60 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+func Test_nameRule_AppliesTo(t *testing.T) {
+	tests := []struct {
+		name         string
+		syntax       []parser.TokenValue
+		relativePath string
+		want         bool
+	}{
+		{name: "entry name", syntax: parts(parser.TokenValue{Token: parser.Text, Value: "*.o"}), relativePath: "a.o", want: true},
+		{name: "directory entry", syntax: parts(parser.TokenValue{Token: parser.Text, Value: "build"}), relativePath: "build/", want: true},
+		{name: "not recursive", syntax: parts(parser.TokenValue{Token: parser.Text, Value: "*.o"}), relativePath: "lib/a.o", want: false},
+		{
+			name:         "recursive",
+			syntax:       parts(parser.TokenValue{Token: parser.Recursive}, parser.TokenValue{Token: parser.Text, Value: "*.o"}),
+			relativePath: "lib/a.o",
+			want:         true,
+		},
+		{
+			name:         "any pattern",
+			syntax:       parts(parser.TokenValue{Token: parser.Text, Value: "a"}, parser.TokenValue{Token: parser.Text, Value: "b"}),
+			relativePath: "b",
+			want:         true,
+		},
+		{name: "clear matches every entry", syntax: parts(parser.TokenValue{Token: parser.Clear}), relativePath: "x", want: true},
+		{name: "character class", syntax: parts(parser.TokenValue{Token: parser.Text, Value: "[Tt]humbs.db"}), relativePath: "thumbs.db", want: true},
+		{name: "negated class", syntax: parts(parser.TokenValue{Token: parser.Text, Value: "[!a]*"}), relativePath: "abc", want: false},
+		{name: "question mark", syntax: parts(parser.TokenValue{Token: parser.Text, Value: "?.c"}), relativePath: "a.c", want: true},
+		{name: "literal dollar", syntax: parts(parser.TokenValue{Token: parser.Text, Value: "*$"}), relativePath: "file$", want: true},
+		{name: "escape", syntax: parts(parser.TokenValue{Token: parser.Text, Value: `\*`}), relativePath: "a", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewNameRule(tt.name, tt.syntax)
+			if err != nil {
+				t.Fatalf("NewNameRule() error = %v", err)
+			}
+			if got := r.(EvaluatingRule).AppliesTo(tt.relativePath); got != tt.want {
+				t.Errorf("AppliesTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_nameRule_Evaluate(t *testing.T) {
+	r, _ := NewNameRule("build", parts(parser.TokenValue{Token: parser.Text, Value: "build"}))
+	got, err := r.(EvaluatingRule).Evaluate("build/")
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if got != ExcludeAndTerminate {
+		t.Errorf("Evaluate() = %v, want %v", got, ExcludeAndTerminate)
+	}
+}
@@ -3,28 +3,16 @@ package rules
 import (
 	"bytes"
 	"fmt"
-	"strings"
 
 	"github.com/jimschubert/ignore/parser"
 )
 
-// rootedFileRule is a special case which applies to only files in the root (same path as ignore file)
+// rootedFileRule is a fileRule anchored to the directory of its ignore file, as is any gitignore pattern with a
+// separator at its beginning or middle (e.g. /*.ext or docs/*.md)
 type rootedFileRule struct {
 	fileRule
 }
 
-func (r rootedFileRule) Evaluate(relativePath string) (Operation, error) {
-	return evaluateRule(r, relativePath)
-}
-
-func (r rootedFileRule) AppliesTo(relativePath string) bool {
-	subPath := strings.Contains(strings.TrimPrefix(relativePath, "/"), "/")
-	if subPath {
-		return false
-	}
-	return r.fileRule.AppliesTo(relativePath)
-}
-
 func (r rootedFileRule) GoString() string {
 	b := bytes.Buffer{}
 	b.WriteString("fileRule {")
@@ -34,18 +22,24 @@ func (r rootedFileRule) GoString() string {
 }
 
 func NewRootedFileRule(raw string, syntax []parser.TokenValue) (Rule, error) {
-	f, err := NewFileRule(raw, syntax)
+	pattern, err := filePattern(raw)
 	if err != nil {
 		return rule{}, err
 	}
 
 	return &rootedFileRule{
-		fileRule: *(f.(*fileRule)),
+		fileRule: fileRule{
+			rule:            rule{raw: raw, syntax: syntax},
+			definedExt:      literalExtension(raw),
+			filenamePattern: pattern,
+		},
 	}, nil
 }
 
 // Forces compilation error if interface contract changes (for any reflection use cases)
 var (
-	_ Rule           = &rootedFileRule{}
-	_ EvaluatingRule = &rootedFileRule{}
+	_ Rule               = &rootedFileRule{}
+	_ EvaluatingRule     = &rootedFileRule{}
+	_ FSEvaluatingRule   = &rootedFileRule{}
+	_ PathEvaluatingRule = &rootedFileRule{}
 )
@@ -39,13 +39,17 @@ func TestNewRootedFileRule(t *testing.T) {
 		},
 
 		{
-			name: "new with error",
+			name: "new with unknown character class",
 			args: args{
-				raw:    `/path/to/*g(-z]+ng`,
+				raw:    `/path/to/*g[[:nope:]]ng`,
 				syntax: fooSyntax,
 			},
-			want:    &rule{},
-			wantErr: true,
+			want: &rootedFileRule{
+				fileRule: fileRule{
+					rule:            rule{raw: `/path/to/*g[[:nope:]]ng`, syntax: fooSyntax},
+					filenamePattern: regexp.MustCompile(`^path/to/[^/]*g` + neverMatchesPattern + `ng$`),
+				},
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,188 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+// rsyncRule is a filter rule following rsync's semantics. A pattern without a slash (or **) matches the final
+// component of a path, one with a leading slash is anchored at the transfer root, and one with a trailing slash
+// matches only directories.
+type rsyncRule struct {
+	rule
+	action  string
+	invert  bool
+	dirOnly bool
+	pattern *regexp.Regexp
+}
+
+func (r rsyncRule) Evaluate(relativePath string) (Operation, error) {
+	return evaluateRule(r, relativePath)
+}
+
+func (r rsyncRule) EvaluateFS(fsys fs.FS, relativePath string) (Operation, error) {
+	return evaluateRuleFS(r, fsys, relativePath)
+}
+
+func (r rsyncRule) EvaluatePath(relativePath string, isDir bool) (Operation, error) {
+	return evaluateRulePath(r, relativePath, isDir)
+}
+
+// Exclude is the operation of a matching rule. Include and show rules include, exclude and hide rules exclude and
+// terminate (rsync doesn't descend into an excluded directory), and the remaining rules don't affect the transfer.
+func (r rsyncRule) Exclude() Operation {
+	switch {
+	case r.transferIgnores():
+		return Noop
+	case r.action == parser.RsyncInclude || r.action == parser.RsyncShow:
+		return Include
+	default:
+		return ExcludeAndTerminate
+	}
+}
+
+// transferIgnores determines whether the rule is irrelevant to what is transferred, as is the case for rules
+// applying only to deletion on the receiving side.
+func (r rsyncRule) transferIgnores() bool {
+	if r.action == parser.RsyncProtect || r.action == parser.RsyncRisk {
+		return true
+	}
+	for _, part := range r.Syntax() {
+		if part.Token == parser.Modifier && strings.Contains(part.Value, "r") && !strings.Contains(part.Value, "s") {
+			return true
+		}
+	}
+	return false
+}
+
+func (r rsyncRule) AppliesTo(relativePath string) bool {
+	return r.AppliesToFS(nil, relativePath)
+}
+
+func (r rsyncRule) AppliesToFS(fsys fs.FS, relativePath string) bool {
+	return r.matches(relativePath, kindOf(fsys, relativePath))
+}
+
+func (r rsyncRule) AppliesToPath(relativePath string, isDir bool) bool {
+	return r.matches(relativePath, kindFor(isDir))
+}
+
+func (r rsyncRule) matches(relativePath string, kind pathKind) bool {
+	if strings.HasSuffix(relativePath, "/") || strings.HasSuffix(relativePath, string(filepath.Separator)) {
+		kind = directoryKind
+	}
+
+	if r.dirOnly && kind == fileKind {
+		return r.invert
+	}
+
+	matched := r.pattern.MatchString(strings.TrimSuffix(filepath.ToSlash(relativePath), "/"))
+	return matched != r.invert
+}
+
+func (r rsyncRule) GoString() string {
+	b := bytes.Buffer{}
+	b.WriteString("rsyncRule {")
+	b.WriteString(fmt.Sprintf("\trule:\t%#v", r.rule))
+	b.WriteString(fmt.Sprintf("\taction:\t%s", r.action))
+	b.WriteString(fmt.Sprintf("\tpattern:\t%s", r.pattern.String()))
+	b.WriteString("}")
+	return b.String()
+}
+
+// rsyncPattern converts an rsync filter pattern (without any trailing slash) to a regular expression
+func rsyncPattern(pattern string) (*regexp.Regexp, error) {
+	buf := bytes.Buffer{}
+	if strings.HasPrefix(pattern, "/") {
+		pattern = strings.TrimPrefix(pattern, "/")
+		buf.WriteString("^")
+	} else {
+		// : an unanchored pattern matches at the end of the path, on a component boundary
+		buf.WriteString("(?:^|/)")
+	}
+
+	// : a trailing /*** matches the directory itself as well as everything within it
+	contents := strings.HasSuffix(pattern, "/***")
+	pattern = strings.TrimSuffix(pattern, "/***")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		current := runes[i]
+		switch {
+		case current == '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				for i+1 < len(runes) && runes[i+1] == '*' {
+					i++
+				}
+				buf.WriteString(".*")
+			} else {
+				buf.WriteString("[^/]*")
+			}
+		case current == '?':
+			buf.WriteString("[^/]")
+		case current == '[':
+			class, end := bracketExpression(runes, i, bracketSyntax{exclude: "/"})
+			if end < 0 {
+				// : an unterminated bracket expression is matched literally
+				buf.WriteString(`\[`)
+				continue
+			}
+			buf.WriteString(class)
+			i = end
+		case current == '\\' && i+1 < len(runes):
+			i++
+			buf.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(current)))
+		}
+	}
+
+	if contents {
+		buf.WriteString("(?:/.*)?")
+	}
+	buf.WriteString("$")
+	return regexp.Compile(buf.String())
+}
+
+// NewRsyncRule constructs an rsync filter rule from the Filter, Modifier and Text tokens of the rsync filter parser
+func NewRsyncRule(raw string, syntax []parser.TokenValue) (Rule, error) {
+	action, modifiers, pattern := "", "", ""
+	for _, part := range syntax {
+		switch part.Token {
+		case parser.Filter:
+			action = part.Value
+		case parser.Modifier:
+			modifiers = part.Value
+		case parser.Text:
+			pattern = part.Value
+		}
+	}
+
+	dirOnly := strings.HasSuffix(pattern, "/") && len(pattern) > 1
+	compiled, err := rsyncPattern(strings.TrimSuffix(pattern, "/"))
+	if err != nil {
+		return rule{}, err
+	}
+
+	return &rsyncRule{
+		rule:    rule{raw: raw, syntax: syntax},
+		action:  action,
+		invert:  strings.Contains(modifiers, "!"),
+		dirOnly: dirOnly,
+		pattern: compiled,
+	}, nil
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Rule               = &rsyncRule{}
+	_ EvaluatingRule     = &rsyncRule{}
+	_ FSEvaluatingRule   = &rsyncRule{}
+	_ PathEvaluatingRule = &rsyncRule{}
+)
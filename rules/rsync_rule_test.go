@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+func rsyncRuleFor(t *testing.T, line string) Rule {
+	t.Helper()
+	syntax, err := parser.NewRsyncFilterParser().ParseLine(line)
+	if err != nil {
+		t.Fatalf("ParseLine() error = %v", err)
+	}
+	r, err := NewRsyncRule(line, syntax)
+	if err != nil {
+		t.Fatalf("NewRsyncRule() error = %v", err)
+	}
+	return r
+}
+
+func Test_rsyncRule_AppliesToPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		line         string
+		relativePath string
+		isDir        bool
+		want         bool
+	}{
+		{name: "final component", line: "- *.o", relativePath: "a/b/c.o", want: true},
+		{name: "star does not cross directories", line: "- a*c", relativePath: "ab/c", want: false},
+		{name: "unanchored with slash matches end of path", line: "- foo/bar", relativePath: "x/foo/bar", want: true},
+		{name: "unanchored on component boundary", line: "- foo/bar", relativePath: "xfoo/bar", want: false},
+		{name: "anchored", line: "- /foo", relativePath: "x/foo", want: false},
+		{name: "anchored at root", line: "- /foo", relativePath: "foo", want: true},
+		{name: "double star", line: "- /a/**/c", relativePath: "a/b/d/c", want: true},
+		{name: "directory only", line: "- build/", relativePath: "build", isDir: true, want: true},
+		{name: "directory only skips files", line: "- build/", relativePath: "build", want: false},
+		{name: "triple star directory", line: "+ /src/***", relativePath: "src", isDir: true, want: true},
+		{name: "triple star contents", line: "+ /src/***", relativePath: "src/a/b", want: true},
+		{name: "character class", line: "- file[!0-9]", relativePath: "file1", want: false},
+		{name: "leading bracket in character class", line: "- []a]", relativePath: "]", want: true},
+		{name: "escaped bracket in character class", line: `- [\]]x`, relativePath: "]x", want: true},
+		{name: "unterminated character class with escape", line: `- [\]x`, relativePath: "[]x", want: true},
+		{name: "negated character class excludes slash", line: "- a[!b]c", relativePath: "a/c", want: false},
+		{name: "inverted", line: "-! *.c", relativePath: "a.h", want: true},
+		{name: "inverted match", line: "-! *.c", relativePath: "a.c", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rsyncRuleFor(t, tt.line)
+			if got := r.(PathEvaluatingRule).AppliesToPath(tt.relativePath, tt.isDir); got != tt.want {
+				t.Errorf("AppliesToPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_rsyncRule_EvaluatePath(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want Operation
+	}{
+		{name: "include", line: "+ a", want: Include},
+		{name: "show", line: "S a", want: Include},
+		{name: "exclude terminates", line: "- a", want: ExcludeAndTerminate},
+		{name: "hide terminates", line: "H a", want: ExcludeAndTerminate},
+		{name: "protect does not affect transfer", line: "P a", want: Noop},
+		{name: "risk does not affect transfer", line: "R a", want: Noop},
+		{name: "receiver only does not affect transfer", line: "-r a", want: Noop},
+		{name: "sender side", line: "-s a", want: ExcludeAndTerminate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rsyncRuleFor(t, tt.line)
+			got, err := r.(PathEvaluatingRule).EvaluatePath("a", false)
+			if err != nil {
+				t.Fatalf("EvaluatePath() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EvaluatePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
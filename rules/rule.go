@@ -1,6 +1,8 @@
 package rules
 
 import (
+	"io/fs"
+
 	"github.com/jimschubert/ignore/parser"
 )
 
@@ -50,6 +52,20 @@ func (b rule) Negated() bool {
 	return len(syntax) > 0 && syntax[0].Token == parser.Negate
 }
 
+// Flags provides the modifiers declared by the rule's syntax, such as Syncthing's (?i) and (?d) prefixes
+func (b rule) Flags() Flags {
+	var flags Flags
+	for _, part := range b.Syntax() {
+		switch part.Token {
+		case parser.CaseInsensitive:
+			flags |= CaseInsensitive
+		case parser.Deletable:
+			flags |= Deletable
+		}
+	}
+	return flags
+}
+
 // func (b rule) Pattern() string {
 // 	syntax := b.Syntax()
 // 	if len(syntax) == 0 {
@@ -78,6 +94,7 @@ type Rule interface {
 	Include() Operation
 	Exclude() Operation
 	Negated() bool
+	Flags() Flags
 }
 
 // EvaluatingRule is a Rule which can be evaluated against a target path
@@ -87,17 +104,52 @@ type EvaluatingRule interface {
 	Evaluate(relativePath string) (Operation, error)
 }
 
+// FSEvaluatingRule is an EvaluatingRule which can determine whether the target path is a directory by consulting a
+// file system other than the operating system's. A Processor falls back to Evaluate for rules which aren't.
+type FSEvaluatingRule interface {
+	EvaluatingRule
+	// AppliesToFS is AppliesTo, but determines whether relativePath is a directory by consulting fsys rather than the process working directory
+	AppliesToFS(fsys fs.FS, relativePath string) bool
+	// EvaluateFS is Evaluate, but determines whether relativePath is a directory by consulting fsys rather than the process working directory
+	EvaluateFS(fsys fs.FS, relativePath string) (Operation, error)
+}
+
+// PathEvaluatingRule is an EvaluatingRule which can be evaluated against a target path already known to be a directory
+// (or not). A Processor falls back to EvaluateFS, or Evaluate, for rules which aren't.
+type PathEvaluatingRule interface {
+	EvaluatingRule
+	// AppliesToPath is AppliesTo for a path already known to be a directory (or not), without consulting any file system
+	AppliesToPath(relativePath string, isDir bool) bool
+	// EvaluatePath is Evaluate for a path already known to be a directory (or not), without consulting any file system
+	EvaluatePath(relativePath string, isDir bool) (Operation, error)
+}
+
 // evaluateRule is a helper for checking if evaluatingRule is applicable, then determining Rule.Include vs Rule.Exclude (supporting Rule.Negated logic)
 func evaluateRule(evaluatingRule EvaluatingRule, relativePath string) (Operation, error) {
-	if evaluatingRule.AppliesTo(relativePath) {
-		if evaluatingRule.Negated() {
-			return evaluatingRule.Include(), nil
+	return operationFor(evaluatingRule, evaluatingRule.AppliesTo(relativePath)), nil
+}
+
+// evaluateRuleFS is evaluateRule, for rules consulting fsys
+func evaluateRuleFS(evaluatingRule FSEvaluatingRule, fsys fs.FS, relativePath string) (Operation, error) {
+	return operationFor(evaluatingRule, evaluatingRule.AppliesToFS(fsys, relativePath)), nil
+}
+
+// evaluateRulePath is evaluateRule, for paths whose type is already known
+func evaluateRulePath(evaluatingRule PathEvaluatingRule, relativePath string, isDir bool) (Operation, error) {
+	return operationFor(evaluatingRule, evaluatingRule.AppliesToPath(relativePath, isDir)), nil
+}
+
+// operationFor determines Rule.Include vs Rule.Exclude (supporting Rule.Negated logic) for a rule which applies, or Noop otherwise
+func operationFor(r Rule, applies bool) Operation {
+	if applies {
+		if r.Negated() {
+			return r.Include()
 		}
 
-		return evaluatingRule.Exclude(), nil
+		return r.Exclude()
 	}
 
-	return Noop, nil
+	return Noop
 }
 
 var (
@@ -0,0 +1,169 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+// syncthingRule is a rule following Syncthing's .stignore semantics. An unrooted pattern matches at any depth, and
+// a pattern matching a directory also matches everything within it.
+type syncthingRule struct {
+	rule
+	pattern *regexp.Regexp
+}
+
+func (s syncthingRule) Evaluate(relativePath string) (Operation, error) {
+	return evaluateRule(s, relativePath)
+}
+
+func (s syncthingRule) EvaluateFS(fsys fs.FS, relativePath string) (Operation, error) {
+	return evaluateRuleFS(s, fsys, relativePath)
+}
+
+func (s syncthingRule) EvaluatePath(relativePath string, isDir bool) (Operation, error) {
+	return evaluateRulePath(s, relativePath, isDir)
+}
+
+// AppliesTo for Syncthing doesn't distinguish files from directories, so no file system is consulted
+func (s syncthingRule) AppliesTo(relativePath string) bool {
+	return s.matches(relativePath)
+}
+
+func (s syncthingRule) AppliesToFS(_ fs.FS, relativePath string) bool {
+	return s.matches(relativePath)
+}
+
+func (s syncthingRule) AppliesToPath(relativePath string, _ bool) bool {
+	return s.matches(relativePath)
+}
+
+func (s syncthingRule) matches(relativePath string) bool {
+	return s.pattern.MatchString(strings.TrimSuffix(filepath.ToSlash(relativePath), "/"))
+}
+
+func (s syncthingRule) GoString() string {
+	b := bytes.Buffer{}
+	b.WriteString("syncthingRule {")
+	b.WriteString(fmt.Sprintf("\trule:\t%#v", s.rule))
+	b.WriteString(fmt.Sprintf("\tpattern:\t%s", s.pattern.String()))
+	b.WriteString("}")
+	return b.String()
+}
+
+// syncthingGlobs expands a pattern to the globs Syncthing matches for it: unrooted patterns also match beneath any
+// directory, and (unless the pattern ends in /**) so does everything within a matching directory.
+func syncthingGlobs(pattern string, rooted bool) []string {
+	bases := []string{pattern}
+	if !rooted && !strings.HasPrefix(pattern, "**/") {
+		bases = append(bases, "**/"+pattern)
+	}
+
+	globs := make([]string, 0, len(bases)*2)
+	for _, base := range bases {
+		switch {
+		case strings.HasSuffix(base, "/**"):
+			globs = append(globs, base)
+		case strings.HasSuffix(base, "/"):
+			globs = append(globs, base+"**")
+		default:
+			globs = append(globs, base, base+"/**")
+		}
+	}
+	return globs
+}
+
+// syncthingGlob converts a Syncthing glob to (unanchored) regular expression syntax
+func syncthingGlob(glob string) string {
+	buf := bytes.Buffer{}
+	group := 0
+
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		current := runes[i]
+		switch {
+		case current == '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				i++
+				buf.WriteString(".*")
+			} else {
+				buf.WriteString("[^/]*")
+			}
+		case current == '?':
+			buf.WriteString("[^/]")
+		case current == '[':
+			class, end := bracketExpression(runes, i, bracketSyntax{})
+			if end < 0 {
+				// : an unterminated bracket expression is matched literally
+				buf.WriteString(`\[`)
+				continue
+			}
+			buf.WriteString(class)
+			i = end
+		case current == '{':
+			group++
+			buf.WriteString("(?:")
+		case current == '}' && group > 0:
+			group--
+			buf.WriteString(")")
+		case current == ',' && group > 0:
+			buf.WriteString("|")
+		case current == '\\' && i+1 < len(runes):
+			i++
+			buf.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(current)))
+		}
+	}
+
+	return buf.String()
+}
+
+// NewSyncthingRule constructs a rule following .stignore semantics from the tokens of the stignore parser. The
+// rule's Flags report the (?i) and (?d) prefixes.
+func NewSyncthingRule(raw string, syntax []parser.TokenValue) (Rule, error) {
+	pattern := ""
+	rooted := false
+	for _, part := range syntax {
+		switch part.Token {
+		case parser.Text:
+			pattern = part.Value
+		case parser.RootedMarker:
+			rooted = true
+		}
+	}
+
+	r := rule{raw: raw, syntax: syntax}
+	alternatives := make([]string, 0)
+	for _, glob := range syncthingGlobs(pattern, rooted) {
+		alternatives = append(alternatives, syncthingGlob(glob))
+	}
+
+	expression := `^(?:` + strings.Join(alternatives, "|") + `)$`
+	if r.Flags().Has(CaseInsensitive) {
+		expression = `(?i)` + expression
+	}
+
+	compiled, err := regexp.Compile(expression)
+	if err != nil {
+		return rule{}, err
+	}
+
+	return &syncthingRule{
+		rule:    r,
+		pattern: compiled,
+	}, nil
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Rule               = &syncthingRule{}
+	_ EvaluatingRule     = &syncthingRule{}
+	_ FSEvaluatingRule   = &syncthingRule{}
+	_ PathEvaluatingRule = &syncthingRule{}
+)
@@ -0,0 +1,79 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+func Test_syncthingRule_AppliesTo(t *testing.T) {
+	tests := []struct {
+		name         string
+		line         string
+		relativePath string
+		want         bool
+	}{
+		{name: "unrooted at root", line: "foo", relativePath: "foo", want: true},
+		{name: "unrooted at depth", line: "foo", relativePath: "a/b/foo", want: true},
+		{name: "unrooted with slash at depth", line: "foo/bar", relativePath: "a/foo/bar", want: true},
+		{name: "directory contents", line: "foo", relativePath: "a/foo/x/y", want: true},
+		{name: "partial name", line: "foo", relativePath: "foobar", want: false},
+		{name: "rooted", line: "/foo", relativePath: "a/foo", want: false},
+		{name: "rooted at root", line: "/foo", relativePath: "foo/x", want: true},
+		{name: "trailing slash matches contents", line: "cache/", relativePath: "cache/x", want: true},
+		{name: "trailing slash not the directory", line: "cache/", relativePath: "cache", want: false},
+		{name: "star within name", line: "*.tmp", relativePath: "a/b.tmp", want: true},
+		{name: "star does not cross directories", line: "/a*c", relativePath: "ab/c", want: false},
+		{name: "double star crosses directories", line: "/a**c", relativePath: "ab/c", want: true},
+		{name: "question mark", line: "?.txt", relativePath: "a.txt", want: true},
+		{name: "character class", line: "file[!0-9]", relativePath: "file1", want: false},
+		{name: "leading bracket in character class", line: "[]a]", relativePath: "]", want: true},
+		{name: "escaped bracket in character class", line: `[\]a]`, relativePath: "]", want: true},
+		{name: "alternatives", line: "*.{jpg,png}", relativePath: "img/a.png", want: true},
+		{name: "case sensitive", line: "thumbs.db", relativePath: "Thumbs.db", want: false},
+		{name: "case insensitive", line: "(?i)thumbs.db", relativePath: "Thumbs.DB", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syntax, err := parser.NewStignoreParser().ParseLine(tt.line)
+			if err != nil {
+				t.Fatalf("ParseLine() error = %v", err)
+			}
+			r, err := NewSyncthingRule(tt.line, syntax)
+			if err != nil {
+				t.Fatalf("NewSyncthingRule() error = %v", err)
+			}
+			if got := r.(EvaluatingRule).AppliesTo(tt.relativePath); got != tt.want {
+				t.Errorf("AppliesTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_rule_Flags(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want Flags
+	}{
+		{name: "none", line: "foo", want: 0},
+		{name: "case insensitive", line: "(?i)foo", want: CaseInsensitive},
+		{name: "deletable", line: "(?d)foo", want: Deletable},
+		{name: "both", line: "!(?i)(?d)foo", want: CaseInsensitive | Deletable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syntax, _ := parser.NewStignoreParser().ParseLine(tt.line)
+			r, err := NewSyncthingRule(tt.line, syntax)
+			if err != nil {
+				t.Fatalf("NewSyncthingRule() error = %v", err)
+			}
+			if got := r.Flags(); got != tt.want {
+				t.Errorf("Flags() = %v, want %v", got, tt.want)
+			}
+			if !r.Flags().Has(tt.want) {
+				t.Errorf("Flags().Has(%v) = false", tt.want)
+			}
+		})
+	}
+}
@@ -1,15 +1,86 @@
 package rules
 
 import (
+	"bytes"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 )
 
+// pathKind describes what is known about the type of the path being evaluated
+type pathKind int
+
+const (
+	unknownKind pathKind = iota
+	fileKind
+	directoryKind
+)
+
+// kindFor converts a known directory flag to a pathKind
+func kindFor(isDir bool) pathKind {
+	if isDir {
+		return directoryKind
+	}
+	return fileKind
+}
+
+// kindOf determines whether relativePath is a file or directory in fsys. A nil fsys consults the operating system,
+// relative to the process working directory.
+func kindOf(fsys fs.FS, relativePath string) pathKind {
+	var fileInfo fs.FileInfo
+	var err error
+	if fsys == nil {
+		fileInfo, err = os.Stat(relativePath)
+	} else {
+		name := strings.TrimSuffix(filepath.ToSlash(relativePath), "/")
+		if !fs.ValidPath(name) {
+			return unknownKind
+		}
+		fileInfo, err = fs.Stat(fsys, name)
+	}
+
+	if err != nil {
+		return unknownKind
+	}
+
+	if fileInfo.IsDir() {
+		return directoryKind
+	}
+	return fileKind
+}
+
+// separatorPattern matches the operating system's path separator
+var separatorPattern = regexp.QuoteMeta(string(os.PathSeparator))
+
+// anyDirectoriesPattern matches zero or more leading directories
+var anyDirectoriesPattern = "(?:.*" + separatorPattern + ")?"
+
+// anchored determines whether an ignore-pattern style glob is anchored to the directory of its ignore file, as is any
+// glob with a separator at its beginning or middle. Otherwise, the glob may match at any depth.
+func anchored(input string) bool {
+	return strings.Contains(strings.TrimSuffix(strings.TrimPrefix(input, "!"), "/"), "/")
+}
+
+// literalExtension provides the extension of pattern (e.g. .txt for *.txt) when it contains no glob characters, as
+// any path matching pattern must then share it. Otherwise, such as for *.tar*, which matches x.tar.gz, it's empty.
+func literalExtension(pattern string) string {
+	ext := filepath.Ext(pattern)
+	if strings.ContainsAny(ext, `*?[\`) {
+		return ""
+	}
+	return ext
+}
+
+// unanchoredFilePattern is filePattern, for a glob which may match at any depth (e.g. *.log matches a/b.log)
+func unanchoredFilePattern(input string) (*regexp.Regexp, error) {
+	return regexp.Compile(fmt.Sprintf("^%s%s$", anyDirectoriesPattern, globPattern(strings.TrimPrefix(input, "!"))))
+}
+
 // filePattern builds up a regular expression from an ignore-pattern style glob
 func filePattern(input string) (*regexp.Regexp, error) {
-	// TODO: Consider platform specific library for converting input glob to regex.
 	globCleanup := input
 	if strings.HasPrefix(input, "!") {
 		// drop leading negation character
@@ -19,21 +90,156 @@ func filePattern(input string) (*regexp.Regexp, error) {
 		// drop leading path separator character
 		globCleanup = strings.TrimPrefix(globCleanup, "/")
 	}
-	// dots in file pattern are explicit characters
-	globCleanup = strings.ReplaceAll(globCleanup, ".", `\Q.\E`)
-	// $ symbols in file pattern are explicit characters
-	globCleanup = strings.ReplaceAll(globCleanup, "$", `\Q$\E`)
-	// double-asterisk is redundant
-	globCleanup = strings.ReplaceAll(globCleanup, "**", "*")
-	// non-greedy match * as 0..n characters
-	globCleanup = strings.ReplaceAll(globCleanup, "*", ".*?")
 	globCleanup = strings.TrimPrefix(globCleanup, "/")
 
-	if os.PathSeparator == '\\' {
-		globCleanup = strings.ReplaceAll(globCleanup, "/", regexp.QuoteMeta("\\"))
-	} else {
-		// *nix path separator needs to be escaped
-		globCleanup = strings.ReplaceAll(globCleanup, `/`, `\/`)
+	return regexp.Compile(fmt.Sprintf("^%s$", globPattern(globCleanup)))
+}
+
+// globPattern converts an ignore-pattern style glob to regular expression syntax, matching the operating system's
+// path separator in place of /. As with git:
+//   - * matches anything other than the separator, as do ? (a single character) and bracket expressions (e.g. [a-z],
+//     [!abc] or [[:alpha:]])
+//   - a leading **/ matches in all directories, so **/foo matches foo at any depth
+//   - a trailing /** matches everything within a directory, so foo/** matches foo/a and foo/a/b, but not foo itself
+//   - /**/ matches zero or more directories, so a/**/b matches a/b, a/x/b and a/x/y/b
+//   - ** alone matches everything, while any other consecutive asterisks are treated as a single *
+//
+// A backslash escapes the following character, and everything else is literal.
+func globPattern(glob string) string {
+	buf := bytes.Buffer{}
+
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		current := runes[i]
+		switch {
+		case current == '*':
+			end := i
+			for end+1 < len(runes) && runes[end+1] == '*' {
+				end++
+			}
+
+			leading := i == 0 || runes[i-1] == '/'
+			trailing := end+1 == len(runes) || runes[end+1] == '/'
+			switch {
+			case end == i || !leading || !trailing:
+				buf.WriteString("[^" + separatorPattern + "]*")
+			case end+1 == len(runes) && i == 0:
+				buf.WriteString(".*")
+			case end+1 == len(runes):
+				// : the separator preceding a trailing ** has already been written, and the directory itself isn't matched
+				buf.WriteString(".+")
+			default:
+				// : a leading **/, or /**/, matches zero or more directories (including the following separator)
+				buf.WriteString(anyDirectoriesPattern)
+				end++
+			}
+			i = end
+		case current == '?':
+			buf.WriteString("[^" + separatorPattern + "]")
+		case current == '[':
+			class, end := bracketExpression(runes, i, bracketSyntax{exclude: separatorPattern})
+			if end < 0 {
+				// : an unterminated bracket expression is matched literally
+				buf.WriteString(`\[`)
+				continue
+			}
+			buf.WriteString(class)
+			i = end
+		case current == '\\' && i+1 < len(runes):
+			i++
+			buf.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case current == '/':
+			buf.WriteString(separatorPattern)
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(current)))
+		}
+	}
+
+	return buf.String()
+}
+
+// posixClasses are the character classes supported within a bracket expression, as for git's wildmatch
+var posixClasses = map[string]bool{
+	"[:alnum:]": true, "[:alpha:]": true, "[:blank:]": true, "[:cntrl:]": true, "[:digit:]": true, "[:graph:]": true,
+	"[:lower:]": true, "[:print:]": true, "[:punct:]": true, "[:space:]": true, "[:upper:]": true, "[:xdigit:]": true,
+}
+
+// neverMatchesPattern is a regular expression character class which matches no character at all
+const neverMatchesPattern = `[^\x00-\x{10FFFF}]`
+
+// bracketSyntax describes how the bracket expressions of a glob syntax are interpreted
+type bracketSyntax struct {
+	// exclude lists characters which a negated expression also excludes, such as the path separator
+	exclude string
+	// fnmatch interprets expressions as Python's fnmatch module does: only ! negates, a backslash is a member of the
+	// set, and there are no character classes
+	fnmatch bool
+}
+
+// bracketExpression converts the bracket expression opened at start (e.g. [a-z], [!abc] or [[:alpha:]]) to a regular
+// expression character class, providing the class and the index of the closing ], or -1 if the expression is
+// unterminated. A negated expression (beginning [! or [^) also excludes the characters of syntax.exclude, such as the
+// path separator. As with fnmatch, a ] immediately following [ or [! is a member of the set. An expression naming an
+// unknown character class (e.g. [[:nope:]]) matches nothing.
+func bracketExpression(runes []rune, start int, syntax bracketSyntax) (string, int) {
+	buf := bytes.Buffer{}
+	buf.WriteString("[")
+
+	i := start + 1
+	unknownClass := false
+	negated := i < len(runes) && (runes[i] == '!' || runes[i] == '^' && !syntax.fnmatch)
+	if negated {
+		buf.WriteString("^")
+		i++
+	}
+	if i < len(runes) && runes[i] == ']' {
+		buf.WriteString(`\]`)
+		i++
+	}
+
+	for ; i < len(runes); i++ {
+		current := runes[i]
+		switch {
+		case current == ']':
+			if unknownClass {
+				return neverMatchesPattern, i
+			}
+			if negated {
+				buf.WriteString(syntax.exclude)
+			}
+			buf.WriteString("]")
+			return buf.String(), i
+		case current == '[' && i+1 < len(runes) && runes[i+1] == ':' && !syntax.fnmatch:
+			// : a character class such as [:alpha:] is supported by regular expressions as-is
+			if end := strings.Index(string(runes[i+2:]), ":]"); end >= 0 {
+				class := string(runes[i:])[:end+4]
+				// : as with git, a pattern containing an unknown class never matches
+				if !posixClasses[class] {
+					unknownClass = true
+				}
+				buf.WriteString(class)
+				i += len([]rune(class)) - 1
+				continue
+			}
+			buf.WriteString(`\[`)
+		case current == '\\' && i+1 < len(runes) && !syntax.fnmatch:
+			i++
+			buf.WriteString(classMember(runes[i]))
+		case current == '-':
+			// ranges, such as a-z, are common to both syntaxes
+			buf.WriteRune(current)
+		default:
+			buf.WriteString(classMember(current))
+		}
+	}
+
+	return "", -1
+}
+
+// classMember escapes r for use within a regular expression character class
+func classMember(r rune) string {
+	if strings.ContainsRune(`\[]^-`, r) {
+		return `\` + string(r)
 	}
-	return regexp.Compile(fmt.Sprintf("^%s$", globCleanup))
+	return string(r)
 }
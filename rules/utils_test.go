@@ -0,0 +1,117 @@
+package rules
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func Test_filePattern(t *testing.T) {
+	tests := []struct {
+		glob         string
+		relativePath string
+		want         bool
+	}{
+		{glob: "?.?", relativePath: "a.b", want: true},
+		{glob: "?.?", relativePath: "ab.c", want: false},
+		{glob: "a?c", relativePath: "a/c", want: false},
+		{glob: "file[0-9].txt", relativePath: "file7.txt", want: true},
+		{glob: "file[0-9].txt", relativePath: "filex.txt", want: false},
+		{glob: "[!abc]*", relativePath: "dog", want: true},
+		{glob: "[!abc]*", relativePath: "cat", want: false},
+		{glob: "[^abc]*", relativePath: "cat", want: false},
+		{glob: "a[!b]c", relativePath: "a/c", want: false},
+		{glob: "[[:alpha:]][[:digit:]]", relativePath: "x1", want: true},
+		{glob: "[[:alpha:]][[:digit:]]", relativePath: "11", want: false},
+		{glob: "[]a]", relativePath: "]", want: true},
+		{glob: "[!]a]", relativePath: "]", want: false},
+		{glob: "[a-]", relativePath: "-", want: true},
+		{glob: `[\]]`, relativePath: "]", want: true},
+		{glob: "[abc", relativePath: "[abc", want: true},
+		{glob: `\[abc\]`, relativePath: "[abc]", want: true},
+		{glob: `\[abc\]`, relativePath: "a", want: false},
+		{glob: `\*.txt`, relativePath: "*.txt", want: true},
+		{glob: `\*.txt`, relativePath: "a.txt", want: false},
+		{glob: `what\?`, relativePath: "what?", want: true},
+		{glob: `what\?`, relativePath: "whats", want: false},
+		{glob: "a+b(c)", relativePath: "a+b(c)", want: true},
+		{glob: "/*.go", relativePath: "main.go", want: true},
+		{glob: "*.go", relativePath: "cmd/main.go", want: false},
+		{glob: "**/foo", relativePath: "foo", want: true},
+		{glob: "**/foo", relativePath: "a/b/foo", want: true},
+		{glob: "**/foo", relativePath: "a/bfoo", want: false},
+		{glob: "foo/**", relativePath: "foo/a/b", want: true},
+		{glob: "foo/**", relativePath: "foo/", want: false},
+		{glob: "a/**/b", relativePath: "a/b", want: true},
+		{glob: "a/**/b", relativePath: "a/x/y/b", want: true},
+		{glob: "a/**/b", relativePath: "a/xb", want: false},
+		{glob: "a**b", relativePath: "a/b", want: false},
+		{glob: "a**b", relativePath: "axb", want: true},
+		{glob: "**", relativePath: "a/b", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.glob+" "+tt.relativePath, func(t *testing.T) {
+			pattern, err := filePattern(tt.glob)
+			if err != nil {
+				t.Fatalf("filePattern() error = %v", err)
+			}
+			if got := pattern.MatchString(filepath.FromSlash(tt.relativePath)); got != tt.want {
+				t.Errorf("filePattern(%q) = %s, MatchString(%q) = %v, want %v", tt.glob, pattern, tt.relativePath, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_unanchoredFilePattern(t *testing.T) {
+	tests := []struct {
+		glob         string
+		relativePath string
+		want         bool
+	}{
+		{glob: "*.log", relativePath: "debug.log", want: true},
+		{glob: "*.log", relativePath: "a/b/debug.log", want: true},
+		{glob: "!*.log", relativePath: "a/debug.log", want: true},
+		{glob: "debug.log", relativePath: "a/debug.log", want: true},
+		{glob: "debug.log", relativePath: "a/xdebug.log", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.glob+" "+tt.relativePath, func(t *testing.T) {
+			pattern, err := unanchoredFilePattern(tt.glob)
+			if err != nil {
+				t.Fatalf("unanchoredFilePattern() error = %v", err)
+			}
+			if got := pattern.MatchString(filepath.FromSlash(tt.relativePath)); got != tt.want {
+				t.Errorf("unanchoredFilePattern(%q) = %s, MatchString(%q) = %v, want %v", tt.glob, pattern, tt.relativePath, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_anchored(t *testing.T) {
+	tests := map[string]bool{
+		"*.md":      false,
+		"build/":    false,
+		"!foo":      false,
+		"/foo":      true,
+		"docs/*.md": true,
+		"**/foo":    true,
+		"!a/b/":     true,
+	}
+	for glob, want := range tests {
+		if got := anchored(glob); got != want {
+			t.Errorf("anchored(%q) = %v, want %v", glob, got, want)
+		}
+	}
+}
+
+func Test_filePattern_unknownClass(t *testing.T) {
+	pattern, err := filePattern("[[:nope:]]z")
+	if err != nil {
+		t.Fatalf("filePattern() error = %v", err)
+	}
+
+	for _, path := range []string{"az", "nz", ":z", "[:nope:]z", "z"} {
+		if pattern.MatchString(path) {
+			t.Errorf("filePattern() with an unknown character class matched %q", path)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package strategy
+
+import "io"
+
+// OpenFunc opens the named file, resolved relative to the directory containing a strategy's ignore file
+type OpenFunc func(name string) (io.ReadCloser, error)
+
+// Fallback is implemented by strategies which read an alternative ignore file when the one at DefinitionPath
+// does not exist. For example, npm reads .gitignore from any directory lacking an .npmignore. A strategy listing no
+// fallback names has none.
+type Fallback interface {
+	// FallbackNames lists the base names of alternative ignore files, in order of preference
+	FallbackNames() []string
+}
+
+// Preset is implemented by strategies which contribute definitions beyond those read from ignore files, such as
+// files which a tool always includes or excludes.
+type Preset interface {
+	// Presets provides the strategy's definitions for the root ignore file's directory, reading any supporting
+	// files (e.g. a package manifest) with open.
+	Presets(open OpenFunc) (Presets, error)
+}
+
+// Presets are definitions, in the strategy's own syntax, contributed by a Preset strategy
+type Presets struct {
+	// Source describes the origin of these definitions (as reported for matching rules)
+	Source string
+	// Before is evaluated ahead of (with lower precedence than) any ignore file
+	Before string
+	// After is evaluated following (with higher precedence than) all ignore files
+	After string
+	// ReplacesRoot indicates that the ignore file in the root directory must not be read
+	ReplacesRoot bool
+	// EveryDirectory applies Before and After within every directory, rather than the root alone, for strategies whose
+	// rules only match entries of the directory defining them (e.g. the default ignore list of CVS)
+	EveryDirectory bool
+}
+
+// Precedence is implemented by strategies which may decide a path's outcome by the first matching rule (as with
+// Syncthing or rsync), rather than the last (as with git).
+type Precedence interface {
+	// FirstMatchWins determines whether the first matching rule decides a path's outcome
+	FirstMatchWins() bool
+}
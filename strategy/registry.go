@@ -0,0 +1,130 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Factory creates a new instance of a registered Strategy. A new instance is created for every lookup, as options such
+// as WithIgnoreFilePath may modify the strategy they're applied to.
+type Factory func() Strategy
+
+// registration is a Factory registered under a name, along with the base names of the ignore files it reads
+type registration struct {
+	factory   Factory
+	fileNames []string
+}
+
+var registry = struct {
+	sync.RWMutex
+	byName     map[string]registration
+	byFileName map[string]string
+}{
+	byName:     make(map[string]registration),
+	byFileName: make(map[string]string),
+}
+
+// Register adds a strategy to the registry under name, to be selected by that name (see Lookup) or by the base name of
+// any of fileNames (see ForFile). A name, or file name, may only be registered once.
+//
+// The built-in strategies (gitignore, dockerignore, etc.) are registered by the ignore package, so a program which
+// doesn't otherwise use that package must import it for its side effects to look them up:
+//
+//	import _ "github.com/jimschubert/ignore"
+func Register(name string, factory Factory, fileNames ...string) error {
+	if name == "" {
+		return errors.New("strategy name must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("strategy %q must have a factory", name)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, exists := registry.byName[name]; exists {
+		return fmt.Errorf("strategy %q is already registered", name)
+	}
+	for _, fileName := range fileNames {
+		if existing, exists := registry.byFileName[fileName]; exists {
+			return fmt.Errorf("file name %q is already registered to strategy %q", fileName, existing)
+		}
+	}
+
+	registry.byName[name] = registration{factory: factory, fileNames: append([]string(nil), fileNames...)}
+	for _, fileName := range fileNames {
+		registry.byFileName[fileName] = name
+	}
+	return nil
+}
+
+// unregister removes the strategy registered under name, along with its file names, such that each may be registered
+// again
+func unregister(name string) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	for _, fileName := range registry.byName[name].fileNames {
+		delete(registry.byFileName, fileName)
+	}
+	delete(registry.byName, name)
+}
+
+// MustRegister is like Register, but panics if the strategy can't be registered. It simplifies registration of
+// strategies from an init function.
+func MustRegister(name string, factory Factory, fileNames ...string) {
+	if err := Register(name, factory, fileNames...); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup creates the strategy registered under name, if any. Built-in strategies are registered by importing the
+// ignore package.
+func Lookup(name string) (Strategy, bool) {
+	registry.RLock()
+	r, ok := registry.byName[name]
+	registry.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+	return r.factory(), true
+}
+
+// ForFile creates the strategy registered for the base name of path (e.g. "a/b/.dockerignore" selects the strategy
+// registered for ".dockerignore"), if any. Built-in strategies are registered by importing the ignore package.
+func ForFile(path string) (Strategy, bool) {
+	registry.RLock()
+	name, ok := registry.byFileName[filepath.Base(path)]
+	registry.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+	return Lookup(name)
+}
+
+// Names lists the names of all registered strategies, in sorted order. Built-in strategies are registered by importing
+// the ignore package.
+func Names() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.byName))
+	for name := range registry.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FileNames lists the ignore file base names registered for the strategy name, if any
+func FileNames(name string) []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	return append([]string(nil), registry.byName[name].fileNames...)
+}
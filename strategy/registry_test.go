@@ -0,0 +1,71 @@
+package strategy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jimschubert/ignore/parser"
+)
+
+func TestRegister(t *testing.T) {
+	factory := func() Strategy {
+		return New(".registryignore", parser.NewGitignoreParser(), nil)
+	}
+
+	if err := Register("registry-test", factory, ".registryignore", ".registryignore-alt"); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	t.Cleanup(func() {
+		unregister("registry-test")
+	})
+
+	tests := []struct {
+		name      string
+		register  string
+		fileNames []string
+		factory   Factory
+	}{
+		{name: "empty name", register: "", factory: factory},
+		{name: "nil factory", register: "registry-test-nil"},
+		{name: "duplicate name", register: "registry-test", factory: factory},
+		{name: "duplicate file name", register: "registry-test-other", factory: factory, fileNames: []string{".registryignore-alt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Register(tt.register, tt.factory, tt.fileNames...); err == nil {
+				t.Errorf("Register() expected an error")
+			}
+		})
+	}
+
+	if _, ok := Lookup("registry-test-other"); ok {
+		t.Errorf("Lookup() found a strategy which failed to register")
+	}
+
+	s, ok := Lookup("registry-test")
+	if !ok || s.DefinitionPath() != ".registryignore" {
+		t.Errorf("Lookup() = %v, %v", s, ok)
+	}
+
+	for _, path := range []string{".registryignore", "a/b/.registryignore-alt"} {
+		if s, ok := ForFile(path); !ok || s.DefinitionPath() != ".registryignore" {
+			t.Errorf("ForFile(%q) = %v, %v", path, s, ok)
+		}
+	}
+
+	if _, ok := ForFile("a/.registryignore/other"); ok {
+		t.Errorf("ForFile() should only consider the base name")
+	}
+
+	if got, want := FileNames("registry-test"), []string{".registryignore", ".registryignore-alt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FileNames() = %v, want %v", got, want)
+	}
+
+	found := false
+	for _, name := range Names() {
+		found = found || name == "registry-test"
+	}
+	if !found {
+		t.Errorf("Names() = %v, missing registry-test", Names())
+	}
+}
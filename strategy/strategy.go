@@ -24,3 +24,39 @@ type BuildRulesFrom func(tokens []parser.TokenValue) (rules.Rule, error)
 func (b BuildRulesFrom) RuleFor(tokens []parser.TokenValue) (rules.Rule, error) {
 	return b(tokens)
 }
+
+// New composes a Strategy which parses the ignore file at definitionPath with p, building rules with b.
+func New(definitionPath string, p parser.Parser, b RuleBuilder) Strategy {
+	return composedStrategy{
+		definitionPath: definitionPath,
+		parser:         p,
+		ruleBuilder:    b,
+	}
+}
+
+// composedStrategy is the Strategy returned by New
+type composedStrategy struct {
+	definitionPath string
+	parser         parser.Parser
+	ruleBuilder    RuleBuilder
+}
+
+// DefinitionPath …
+func (c composedStrategy) DefinitionPath() string {
+	return c.definitionPath
+}
+
+// Parser …
+func (c composedStrategy) Parser() parser.Parser {
+	return c.parser
+}
+
+// RuleBuilder …
+func (c composedStrategy) RuleBuilder() RuleBuilder {
+	return c.ruleBuilder
+}
+
+// Forces compilation error if interface contract changes (for any reflection use cases)
+var (
+	_ Strategy = &composedStrategy{}
+)
@@ -0,0 +1,27 @@
+package strategy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jimschubert/ignore/parser"
+	"github.com/jimschubert/ignore/rules"
+)
+
+func TestNew(t *testing.T) {
+	p := parser.NewGitignoreParser()
+	b := BuildRulesFrom(func(tokens []parser.TokenValue) (rules.Rule, error) {
+		return rules.NewEmptyRule("", tokens)
+	})
+
+	got := New(".customignore", p, b)
+	if got.DefinitionPath() != ".customignore" {
+		t.Errorf("DefinitionPath() = %v, want %v", got.DefinitionPath(), ".customignore")
+	}
+	if !reflect.DeepEqual(got.Parser(), p) {
+		t.Errorf("Parser() = %v, want %v", got.Parser(), p)
+	}
+	if got.RuleBuilder() == nil {
+		t.Errorf("RuleBuilder() = nil")
+	}
+}
@@ -0,0 +1,226 @@
+package ignore
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jimschubert/ignore/internal/util"
+	"github.com/jimschubert/ignore/parser"
+	"github.com/jimschubert/ignore/rules"
+	"github.com/jimschubert/ignore/strategy"
+	"github.com/jimschubert/ignore/test"
+)
+
+// semicolonCommentParser is a gitignore parser which also treats lines beginning with ; as comments
+type semicolonCommentParser struct{}
+
+func (s semicolonCommentParser) ParseLine(text string) ([]parser.TokenValue, error) {
+	if strings.HasPrefix(text, ";") {
+		return []parser.TokenValue{}, nil
+	}
+	return parser.NewGitignoreParser().ParseLine(text)
+}
+
+func (s semicolonCommentParser) ParseAll(reader io.Reader) ([]parser.TokenValue, error) {
+	return parser.NewParsingHelpers(s).ParseAll(reader)
+}
+
+func (s semicolonCommentParser) ParseAllText(text string) ([]parser.TokenValue, error) {
+	return parser.NewParsingHelpers(s).ParseAllText(text)
+}
+
+// rootedRuleBuilder anchors every pattern to the ignore file's directory
+var rootedRuleBuilder = strategy.BuildRulesFrom(func(tokens []parser.TokenValue) (rules.Rule, error) {
+	if tokens[0].Token == parser.Comment {
+		return rules.NewEmptyRule(util.StringValue(tokens[0].Line), tokens)
+	}
+	return rules.NewRootedFileRule(util.StringValue(tokens[0].Line), tokens)
+})
+
+// suffixRule is a custom rule, implementing only AppliesTo and Evaluate, which excludes paths ending in its pattern
+type suffixRule struct {
+	rules.Rule
+}
+
+func (s suffixRule) AppliesTo(relativePath string) bool {
+	return strings.HasSuffix(relativePath, s.Raw())
+}
+
+func (s suffixRule) Evaluate(relativePath string) (rules.Operation, error) {
+	if s.AppliesTo(relativePath) {
+		return s.Exclude(), nil
+	}
+	return rules.Noop, nil
+}
+
+// suffixRuleBuilder builds a suffixRule for each pattern
+var suffixRuleBuilder = strategy.BuildRulesFrom(func(tokens []parser.TokenValue) (rules.Rule, error) {
+	r, err := rules.NewEmptyRule(util.StringValue(tokens[0].Line), tokens)
+	if err != nil || tokens[0].Token == parser.Comment {
+		return r, err
+	}
+	return suffixRule{r}, nil
+})
+
+// firstMatchStrategy is a custom strategy under which the first matching rule decides a path's outcome
+type firstMatchStrategy struct {
+	strategy.Strategy
+}
+
+func (f firstMatchStrategy) FirstMatchWins() bool {
+	return true
+}
+
+func TestCustomStrategyOptions(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".customignore": "; not a pattern\n*.log\n",
+		".firstmatch":   "a.log\n!a.log\n",
+	})
+	ignoreFile := filepath.Join(root, ".customignore")
+	firstMatchFile := filepath.Join(root, ".firstmatch")
+
+	tests := []struct {
+		name       string
+		opts       []ProcessorOption
+		conditions []AllowTestCondition
+		wantErr    bool
+	}{
+		{
+			name: "with strategy",
+			opts: []ProcessorOption{
+				WithStrategy(strategy.New(ignoreFile, semicolonCommentParser{}, rootedRuleBuilder)),
+			},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: false},
+				{File: "nested/a.log", Allows: true},
+				{File: "; not a pattern", Allows: true},
+			},
+		},
+		{
+			name: "with parser",
+			opts: []ProcessorOption{
+				WithIgnoreFilePath(ignoreFile),
+				WithParser(semicolonCommentParser{}),
+			},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: false},
+				{File: "nested/a.log", Allows: false},
+				{File: "; not a pattern", Allows: true},
+			},
+		},
+		{
+			name: "with rule builder",
+			opts: []ProcessorOption{
+				WithIgnoreFilePath(ignoreFile),
+				WithParser(semicolonCommentParser{}),
+				WithRuleBuilder(rootedRuleBuilder),
+			},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: false},
+				{File: "nested/a.log", Allows: true},
+			},
+		},
+		{
+			name: "with rule builder of evaluating rules",
+			opts: []ProcessorOption{
+				WithPatterns(".secret"),
+				WithRuleBuilder(suffixRuleBuilder),
+			},
+			conditions: []AllowTestCondition{
+				{File: ".secret", Allows: false},
+				{File: "nested/.secret", Allows: false},
+				{File: "a.log", Allows: true},
+			},
+		},
+		{
+			name: "with precedence strategy",
+			opts: []ProcessorOption{
+				WithStrategy(firstMatchStrategy{strategy.New(firstMatchFile, parser.NewGitignoreParser(), rootedRuleBuilder)}),
+			},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: false},
+			},
+		},
+		{
+			name: "with precedence strategy and ignore file path",
+			opts: []ProcessorOption{
+				WithStrategy(firstMatchStrategy{strategy.New(ignoreFile, parser.NewGitignoreParser(), rootedRuleBuilder)}),
+				WithIgnoreFilePath(firstMatchFile),
+			},
+			conditions: []AllowTestCondition{
+				{File: "a.log", Allows: false},
+			},
+		},
+		{
+			name:    "nil strategy",
+			opts:    []ProcessorOption{WithStrategy(nil)},
+			wantErr: true,
+		},
+		{
+			name:    "nil parser",
+			opts:    []ProcessorOption{WithParser(nil)},
+			wantErr: true,
+		},
+		{
+			name:    "nil rule builder",
+			opts:    []ProcessorOption{WithRuleBuilder(nil)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := NewProcessor(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewProcessor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			assertConditions(t, processor, tt.conditions)
+		})
+	}
+}
+
+func TestWithParser_braceExpansion(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".gitignore": "# expanded\n**/{first*,second*}\nfile{1..3}.txt\n",
+	})
+
+	processor, err := NewProcessor(
+		WithParser(parser.NewGitignoreParser(parser.WithBraceExpansion())),
+		WithIgnoreFilePath(filepath.Join(root, ".gitignore")),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	tests := []struct {
+		path        string
+		allows      bool
+		wantLine    int
+		wantPattern string
+	}{
+		{path: "nested/first.txt", allows: false, wantLine: 2, wantPattern: "**/first*"},
+		{path: "nested/second.txt", allows: false, wantLine: 2, wantPattern: "**/second*"},
+		{path: "nested/third.txt", allows: true},
+		{path: "file2.txt", allows: false, wantLine: 3, wantPattern: "file2.txt"},
+		{path: "file4.txt", allows: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			match, err := processor.Explain(filepath.FromSlash(tt.path))
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+			if match.Allowed != tt.allows {
+				t.Errorf("Explain() Allowed = %v, want %v", match.Allowed, tt.allows)
+			}
+			if match.Line != tt.wantLine || match.Pattern != tt.wantPattern {
+				t.Errorf("Explain() matched %s:%d, want %s:%d", match.Pattern, match.Line, tt.wantPattern, tt.wantLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package ignore
+
+import (
+	"io/fs"
+
+	"github.com/jimschubert/ignore/internal/util"
+	"github.com/jimschubert/ignore/rules"
+)
+
+// WalkFunc is the type of function called by Processor.Walk for each visited file or directory. The allowed argument
+// reports whether the Processor allows path. If walking encounters an error, err describes it and allowed is false.
+//
+// Returning fs.SkipDir behaves as it does for fs.WalkDirFunc.
+type WalkFunc func(path string, d fs.DirEntry, allowed bool, err error) error
+
+// Walk walks the file tree rooted at root, calling fn for each visited file or directory along with the Processor's
+// decision for that path. Paths are evaluated relative to root, which should be the directory the ignore rules apply to.
+// When the Processor was created WithFS, root is walked within that file system.
+//
+// Excluded directories are not descended into when nothing beneath them could be re-included.
+func (p *Processor) Walk(root string, fn WalkFunc) error {
+	if err := p.processIgnoreFile(); err != nil {
+		return err
+	}
+
+	negations := p.hasNegations()
+	return p.walkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fn(path, d, false, err)
+		}
+
+		relativePath, err := p.relativeTo(root, path)
+		if err != nil {
+			return fn(path, d, false, err)
+		}
+
+		if relativePath == "." {
+			return fn(path, d, true, nil)
+		}
+
+		// parent directories aren't re-evaluated here: any which terminates evaluation has already been skipped
+		decided, err := p.evaluate(relativePath, util.Ptr(d.IsDir()))
+		if err != nil {
+			return fn(path, d, false, err)
+		}
+
+		op := decided.op
+		allowed := allowedBy(op)
+		if err := fn(path, d, allowed, nil); err != nil {
+			return err
+		}
+
+		if d.IsDir() && !allowed && (op == rules.ExcludeAndTerminate || !negations) {
+			return fs.SkipDir
+		}
+
+		return nil
+	})
+}
+
+// WalkDir walks the file tree rooted at root like filepath.WalkDir (or fs.WalkDir), but calls fn only for files and directories
+// allowed by the Processor. See Walk for how paths are evaluated and excluded directories are skipped.
+func (p *Processor) WalkDir(root string, fn fs.WalkDirFunc) error {
+	return p.Walk(root, func(path string, d fs.DirEntry, allowed bool, err error) error {
+		if err != nil || allowed {
+			return fn(path, d, err)
+		}
+
+		return nil
+	})
+}
+
+// hasNegations determines whether any loaded rule could re-include a previously excluded path
+func (p *Processor) hasNegations() bool {
+	for _, set := range p.ruleSets {
+		for _, rule := range set.rules {
+			if _, ok := rule.Rule.(rules.EvaluatingRule); ok && rule.Negated() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package ignore
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jimschubert/ignore/test"
+)
+
+func TestProcessor_Walk(t *testing.T) {
+	tests := []struct {
+		name   string
+		ignore string
+		want   []string
+	}{
+		{
+			name:   "prunes excluded directories",
+			ignore: "node_modules/\ntarget/\n*.log\n",
+			want: []string{
+				"✓ .",
+				"✓ .gitignore",
+				"✘ debug.log",
+				"✘ node_modules/",
+				"✓ src/",
+				"✓ src/main.go",
+				"✘ src/trace.log",
+				"✘ target/",
+			},
+		},
+		{
+			name:   "prunes excluded directories despite negations",
+			ignore: "node_modules/\n*.log\n!target/keep.log\n!node_modules/keep.log\n",
+			want: []string{
+				"✓ .",
+				"✓ .gitignore",
+				"✘ debug.log",
+				"✘ node_modules/",
+				"✓ src/",
+				"✓ src/main.go",
+				"✘ src/trace.log",
+				"✓ target/",
+				"✓ target/app",
+				"✓ target/keep.log",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := test.Tree(t, map[string]string{
+				".gitignore":            tt.ignore,
+				"debug.log":             "",
+				"node_modules/a/b.js":   "",
+				"src/main.go":           "",
+				"src/trace.log":         "",
+				"target/app":            "",
+				"target/keep.log":       "",
+				"node_modules/c/d.js":   "",
+				"node_modules/keep.log": "",
+			})
+
+			processor, err := NewProcessor(
+				WithGitignoreStrategy(),
+				WithIgnoreFilePath(filepath.Join(root, ".gitignore")),
+			)
+			if err != nil {
+				t.Fatalf("NewProcessor() error = %v", err)
+			}
+
+			got := make([]string, 0)
+			err = processor.Walk(root, func(path string, d fs.DirEntry, allowed bool, err error) error {
+				if err != nil {
+					return err
+				}
+
+				relativePath, _ := filepath.Rel(root, path)
+				relativePath = filepath.ToSlash(relativePath)
+				if d.IsDir() && relativePath != "." {
+					relativePath += "/"
+				}
+
+				mark := "✘"
+				if allowed {
+					mark = "✓"
+				}
+				got = append(got, fmt.Sprintf("%s %s", mark, relativePath))
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Walk()\ngot=\n%v\nwant=\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessor_WalkDir(t *testing.T) {
+	root := test.Tree(t, map[string]string{
+		".gitignore":          "node_modules/\n*.log\n",
+		"debug.log":           "",
+		"node_modules/a/b.js": "",
+		"src/main.go":         "",
+		"src/trace.log":       "",
+	})
+
+	processor, err := NewProcessor(
+		WithGitignoreStrategy(),
+		WithIgnoreFilePath(filepath.Join(root, ".gitignore")),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	got := make([]string, 0)
+	err = processor.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, _ := filepath.Rel(root, path)
+		got = append(got, filepath.ToSlash(relativePath))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkDir() error = %v", err)
+	}
+
+	want := []string{".", ".gitignore", "src", "src/main.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WalkDir() got = %v, want %v", got, want)
+	}
+}